@@ -0,0 +1,91 @@
+// Package authn carries credentials over HTTP headers and gRPC metadata, and
+// the user they authenticate through a request's context.
+package authn
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/quii/go-specs-greet/domain/auth"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// APIKeyHeader carries an API key over HTTP, and bearer tokens go in
+	// the Authorization header.
+	APIKeyHeader = "X-API-Key"
+	// APIKeyMetadata and AuthorizationMetadata are the gRPC equivalents.
+	APIKeyMetadata        = "x-api-key"
+	AuthorizationMetadata = "authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+// FromHeader returns the credentials an HTTP request presents.
+func FromHeader(header http.Header) auth.Credentials {
+	return auth.Credentials{
+		APIKey:      header.Get(APIKeyHeader),
+		BearerToken: bearerToken(header.Get("Authorization")),
+	}
+}
+
+// SetHeader presents credentials on an HTTP request.
+func SetHeader(header http.Header, credentials auth.Credentials) {
+	if credentials.APIKey != "" {
+		header.Set(APIKeyHeader, credentials.APIKey)
+	}
+	if credentials.BearerToken != "" {
+		header.Set("Authorization", bearerPrefix+credentials.BearerToken)
+	}
+}
+
+// FromMetadata returns the credentials an RPC presents.
+func FromMetadata(ctx context.Context) auth.Credentials {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return auth.Credentials{
+		APIKey:      first(md.Get(APIKeyMetadata)),
+		BearerToken: bearerToken(first(md.Get(AuthorizationMetadata))),
+	}
+}
+
+// Metadata is credentials as gRPC request metadata.
+func Metadata(credentials auth.Credentials) map[string]string {
+	md := map[string]string{}
+	if credentials.APIKey != "" {
+		md[APIKeyMetadata] = credentials.APIKey
+	}
+	if credentials.BearerToken != "" {
+		md[AuthorizationMetadata] = bearerPrefix + credentials.BearerToken
+	}
+	return md
+}
+
+// bearerToken is the token in an Authorization value using the Bearer scheme,
+// whose name is case-insensitive.
+func bearerToken(authorization string) string {
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimSpace(authorization[len(bearerPrefix):])
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+type userKey struct{}
+
+// WithUser returns ctx carrying the user a request authenticated as.
+func WithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the user a request authenticated as, or "" if it didn't.
+func UserFrom(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
+}
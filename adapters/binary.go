@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// BuildBinary compiles cmd/binToBuild into a temporary directory and returns
+// the path to the executable. Like StartDockerServer it expects to be called
+// from a test in cmd/*.
+func BuildBinary(t testing.TB, binToBuild string) string {
+	t.Helper()
+
+	binary := filepath.Join(t.TempDir(), binToBuild)
+	build := exec.Command("go", "build", "-o", binary, "../../cmd/"+binToBuild)
+	out, err := build.CombinedOutput()
+	assert.NoError(t, err, string(out))
+	return binary
+}
+
+// Process is a binary running as a child of the test, for tests about how the
+// binary itself behaves, such as what it does when it's signalled.
+type Process struct {
+	Server
+	cmd    *exec.Cmd
+	exited chan error
+}
+
+// RunBinary builds cmd/binToBuild and runs it on a free port, configured like
+// StartServer's servers plus env, until the test finishes. It returns once
+// the binary accepts connections.
+func RunBinary(t testing.TB, binToBuild string, env map[string]string) *Process {
+	t.Helper()
+
+	binary := BuildBinary(t, binToBuild)
+	options := newServerOptions(t, []ServerOption{WithEnv(env)})
+	port := FreePort(t)
+	cmd := exec.Command(binary)
+	cmd.Env = append(os.Environ(), "PORT="+port)
+	for key, value := range options.env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	cmd.Stdout = testWriter{t}
+	cmd.Stderr = testWriter{t}
+	assert.NoError(t, cmd.Start())
+
+	p := &Process{
+		Server: Server{Addr: net.JoinHostPort("127.0.0.1", port), RootCAs: options.rootCAs},
+		cmd:    cmd,
+		exited: make(chan error, 1),
+	}
+	go func() {
+		p.exited <- cmd.Wait()
+	}()
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		<-p.exited
+	})
+	p.waitUntilListening(t)
+	return p
+}
+
+func (p *Process) waitUntilListening(t testing.TB) {
+	t.Helper()
+	deadline := time.Now().Add(startupTimeout)
+	for {
+		conn, err := net.Dial("tcp", p.Addr)
+		if err == nil {
+			_ = conn.Close()
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s never listened on %s: %v", p.cmd.Path, p.Addr, err)
+		}
+		time.Sleep(healthPollInterval)
+	}
+}
+
+// Signal sends sig to the process.
+func (p *Process) Signal(sig os.Signal) error {
+	return p.cmd.Process.Signal(sig)
+}
+
+// Wait waits up to timeout for the process to exit, and returns why it did.
+func (p *Process) Wait(t testing.TB, timeout time.Duration) error {
+	t.Helper()
+	select {
+	case err := <-p.exited:
+		p.exited <- err
+		return err
+	case <-time.After(timeout):
+		t.Fatalf("%s didn't exit within %s", p.cmd.Path, timeout)
+		return nil
+	}
+}
+
+// FreePort returns a port nothing is listening on, for a binary to listen on.
+func FreePort(t testing.TB) string {
+	t.Helper()
+	lis := listen(t)
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	assert.NoError(t, err)
+	assert.NoError(t, lis.Close())
+	return port
+}
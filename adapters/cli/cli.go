@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/domain/moderation"
+	"golang.org/x/text/language"
+)
+
+const (
+	exitOK     = 0
+	exitFailed = 1
+	exitUsage  = 2
+
+	// AdapterName is what this adapter is called in the history.
+	AdapterName = "cli"
+
+	maxLineBytes = 1 << 20
+)
+
+// Reply is a line of --json output. Name is echoed back so batch output can
+// be matched up with its input.
+type Reply struct {
+	Name        string `json:"name"`
+	Message     string `json:"message,omitempty"`
+	Interaction string `json:"interaction"`
+	Locale      string `json:"locale"`
+	Error       *Error `json:"error,omitempty"`
+}
+
+type Error struct {
+	// Code identifies the domain error, see interactions.LookupError.
+	Code   string `json:"code,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// Run is the command line interface, taking the arguments after the program
+// name, e.g. ["greet", "--name", "Mike"], and returning the exit code.
+func Run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		usage(stderr)
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { usage(stderr) }
+	var (
+		name   = flags.String("name", "", "who to interact with")
+		locale = flags.String("locale", "", `preferred languages as an Accept-Language list, e.g. "fr-CA, en"`)
+		batch  = flags.Bool("batch", false, "read names from stdin, one per line")
+		asJSON = flags.Bool("json", false, "write each reply as a line of JSON")
+		mode   = flags.String("moderation", "reject", `what to do with names containing blocked words, "reject", "mask" or "off"`)
+	)
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		usage(stderr)
+		return exitUsage
+	}
+
+	service := greeter.NewService(history.NewInMemory(), nil)
+	switch *mode {
+	case "reject":
+		service.Moderation = moderation.NewPolicy(moderation.Reject, moderation.DefaultBlocklist, moderation.DefaultAllowlist)
+	case "mask":
+		service.Moderation = moderation.NewPolicy(moderation.Mask, moderation.DefaultBlocklist, moderation.DefaultAllowlist)
+	case "off":
+	default:
+		fmt.Fprintf(stderr, "unknown moderation %q\n", *mode)
+		usage(stderr)
+		return exitUsage
+	}
+
+	out := output{stdout: stdout, stderr: stderr, json: *asJSON, locale: interactions.ParseLocale(*locale), service: service}
+
+	interaction, ok := interactions.DefaultRegistry.Lookup(args[0])
+	if !ok {
+		out.fail(args[0], *name, interactions.ErrUnknownInteraction)
+		usage(stderr)
+		return exitUsage
+	}
+
+	if !*batch {
+		return out.reply(interaction, *name)
+	}
+
+	code := exitOK
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(nil, maxLineBytes)
+	for scanner.Scan() {
+		if out.reply(interaction, scanner.Text()) != exitOK {
+			code = exitFailed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "reading stdin: %v\n", err)
+		return exitFailed
+	}
+	return code
+}
+
+func usage(w io.Writer) {
+	var names []string
+	for _, interaction := range interactions.DefaultRegistry.All() {
+		names = append(names, interaction.Name)
+	}
+	fmt.Fprintf(w, `usage: <interaction> [--name NAME | --batch] [--locale LOCALES] [--json] [--moderation MODE]
+
+interactions: %s
+
+  --name NAME       who to interact with
+  --batch           read names from stdin, one per line
+  --locale LOCALES  preferred languages as an Accept-Language list, e.g. "fr-CA, en"
+  --json            write each reply as a line of JSON
+  --moderation MODE what to do with names containing blocked words, "reject"
+                    (the default), "mask" or "off"
+`, strings.Join(names, ", "))
+}
+
+// output writes replies to stdout. Errors go to stdout too in JSON mode, so
+// every batch line gets an answer, and to stderr otherwise.
+type output struct {
+	stdout, stderr io.Writer
+	json           bool
+	locale         language.Tag
+	service        *greeter.Service
+}
+
+func (o output) reply(interaction interactions.Interaction, name string) int {
+	message, err := o.service.Interact(context.Background(), greeter.Call{
+		Interaction: interaction,
+		Locale:      o.locale,
+		Name:        name,
+		User:        localUser(),
+		Adapter:     AdapterName,
+	})
+	if err != nil {
+		o.fail(interaction.Name, name, err)
+		return exitFailed
+	}
+
+	if !o.json {
+		fmt.Fprintln(o.stdout, message)
+		return exitOK
+	}
+	o.writeJSON(Reply{
+		Name:        name,
+		Message:     message,
+		Interaction: interaction.Name,
+		Locale:      o.locale.String(),
+	})
+	return exitOK
+}
+
+func (o output) fail(interaction string, name string, err error) {
+	if !o.json {
+		fmt.Fprintf(o.stderr, "%s: %v\n", interaction, err)
+		return
+	}
+
+	replyErr := &Error{Detail: err.Error()}
+	var domainErr *interactions.Error
+	if errors.As(err, &domainErr) {
+		replyErr.Code = domainErr.Code
+	}
+	o.writeJSON(Reply{
+		Name:        name,
+		Interaction: interaction,
+		Locale:      o.locale.String(),
+		Error:       replyErr,
+	})
+}
+
+func (o output) writeJSON(reply Reply) {
+	if err := json.NewEncoder(o.stdout).Encode(reply); err != nil {
+		fmt.Fprintf(o.stderr, "writing reply: %v\n", err)
+	}
+}
+
+// localUser is who is running the CLI. Having a shell on the machine is all
+// the authentication the CLI asks for.
+func localUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "local"
+}
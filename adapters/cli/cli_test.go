@@ -0,0 +1,82 @@
+package cli_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/cli"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+func TestRun(t *testing.T) {
+	run := func(stdin string, args ...string) (code int, stdout string, stderr string) {
+		var out, errOut bytes.Buffer
+		code = cli.Run(args, strings.NewReader(stdin), &out, &errOut)
+		return code, out.String(), errOut.String()
+	}
+
+	t.Run("writes the reply as a line of text", func(t *testing.T) {
+		code, stdout, stderr := run("", "greet", "--name", "Mike", "--locale", "fr")
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Bonjour, Mike\n", stdout)
+		assert.Zero(t, stderr)
+	})
+
+	t.Run("writes errors to stderr", func(t *testing.T) {
+		code, stdout, stderr := run("", "greet", "--name", "Mi\u0085ke")
+		assert.Equal(t, 1, code)
+		assert.Zero(t, stdout)
+		assert.Equal(t, "greet: "+interactions.ErrNameHasControlCharacters.Error()+"\n", stderr)
+	})
+
+	t.Run("replies to each line of a batch, carrying on past invalid names", func(t *testing.T) {
+		code, stdout, stderr := run("Mike\nMi\u0085ke\n\nChris\n", "greet", "--batch")
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "Hello, Mike\nHello, World\nHello, Chris\n", stdout)
+		assert.Equal(t, "greet: "+interactions.ErrNameHasControlCharacters.Error()+"\n", stderr)
+	})
+
+	t.Run("succeeds when every line of a batch does", func(t *testing.T) {
+		code, stdout, _ := run("Mike\nChris", "curse", "--batch")
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "Go to hell, Mike!\nGo to hell, Chris!\n", stdout)
+	})
+
+	t.Run("answers every line of a JSON batch, errors included", func(t *testing.T) {
+		code, stdout, stderr := run("Mike\nMi\u0085ke\nChris\n", "greet", "--batch", "--json")
+		assert.Equal(t, 1, code)
+		assert.Zero(t, stderr)
+
+		var replies []cli.Reply
+		scanner := bufio.NewScanner(strings.NewReader(stdout))
+		for scanner.Scan() {
+			var reply cli.Reply
+			assert.NoError(t, json.Unmarshal(scanner.Bytes(), &reply))
+			replies = append(replies, reply)
+		}
+		assert.Equal(t, []cli.Reply{
+			{Name: "Mike", Message: "Hello, Mike", Interaction: "greet", Locale: "en"},
+			{Name: "Mi\u0085ke", Interaction: "greet", Locale: "en", Error: &cli.Error{
+				Code:   "name_has_control_characters",
+				Detail: interactions.ErrNameHasControlCharacters.Error(),
+			}},
+			{Name: "Chris", Message: "Hello, Chris", Interaction: "greet", Locale: "en"},
+		}, replies)
+	})
+
+	t.Run("refuses to run without an interaction", func(t *testing.T) {
+		code, _, stderr := run("", "--name", "Mike")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "usage:")
+	})
+
+	t.Run("refuses unknown interactions", func(t *testing.T) {
+		code, _, stderr := run("", "serenade", "--name", "Mike")
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr, "serenade: "+interactions.ErrUnknownInteraction.Error())
+	})
+}
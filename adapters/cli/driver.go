@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// Driver runs the built command line binary at Binary once per interaction.
+type Driver struct {
+	Binary string
+}
+
+func (d Driver) Curse(ctx context.Context, name string) (string, error) {
+	return d.CurseIn(ctx, "", name)
+}
+
+func (d Driver) Greet(ctx context.Context, name string) (string, error) {
+	return d.GreetIn(ctx, "", name)
+}
+
+func (d Driver) CurseIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Cursing.Name, locale, name)
+}
+
+func (d Driver) GreetIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Greeting.Name, locale, name)
+}
+
+func (d Driver) Interact(ctx context.Context, interaction string, locale string, name string) (string, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, d.Binary, interaction, "--json", "--name="+name, "--locale="+locale)
+	cmd.Stderr = &stderr
+
+	out, runErr := cmd.Output()
+	var reply Reply
+	if err := json.Unmarshal(out, &reply); err != nil {
+		if runErr != nil {
+			return "", fmt.Errorf("%w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+		return "", fmt.Errorf("reading reply %q: %w", out, err)
+	}
+
+	if reply.Error != nil {
+		if domainErr, ok := interactions.LookupError(reply.Error.Code); ok {
+			return "", domainErr
+		}
+		return "", errors.New(reply.Error.Detail)
+	}
+	return reply.Message, nil
+}
@@ -0,0 +1,43 @@
+package adapters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/quii/go-specs-greet/domain/auth"
+)
+
+// Servers started by StartServer let TestUser make authenticated interactions
+// with TestAPIKey, or with a bearer token from TestToken.
+const (
+	TestUser   = "acceptance"
+	TestAPIKey = "acceptance-api-key"
+
+	testTokenSecret = "acceptance-token-secret-at-least-32-bytes"
+)
+
+// Servers started by StartServer let a caller make TestThrottleBurst curses
+// in a row, more than any test makes, and ThrottledBurst when they're started
+// with ThrottledEnv.
+const (
+	TestThrottleBurst = 1000000
+	ThrottledBurst    = 3
+)
+
+// TestToken returns a bearer token for TestUser.
+func TestToken() string {
+	return auth.NewTokens([]byte(testTokenSecret)).Issue(TestUser, time.Hour)
+}
+
+// testEnv is how every server started by StartServer is configured.
+var testEnv = map[string]string{
+	"API_KEYS":       TestUser + ":" + TestAPIKey,
+	"TOKEN_SECRET":   testTokenSecret,
+	"THROTTLE_BURST": strconv.Itoa(TestThrottleBurst),
+}
+
+// ThrottledEnv configures a server, with WithEnv, to throttle curses after a
+// few, for the throttling specification.
+var ThrottledEnv = map[string]string{
+	"THROTTLE_BURST": strconv.Itoa(ThrottledBurst),
+}
@@ -3,6 +3,8 @@ package adapters
 import (
 	"context"
 	"fmt"
+	"net"
+	"sync"
 	"testing"
 	"time"
 
@@ -13,22 +15,64 @@ import (
 )
 
 const (
-	startupTimeout = 5 * time.Second
-	dockerfileName = "Dockerfile"
+	startupTimeout     = 5 * time.Second
+	dockerProbeTimeout = 5 * time.Second
+	dockerfileName     = "Dockerfile"
 )
 
+// dockerAvailable asks the Docker daemon for its details, once per test
+// binary, and reports why it couldn't if it doesn't answer. testcontainers
+// panics when it can't find a daemon at all.
+var dockerAvailable = sync.OnceValue(func() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), dockerProbeTimeout)
+	defer cancel()
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		return err
+	}
+	return provider.Health(ctx)
+})
+
+// DockerServer is a running container and where its ports were published,
+// which are random free ports on the host so servers never collide.
+type DockerServer struct {
+	Server
+	Container testcontainers.Container
+}
+
+// adminPorts are the container ports binaries serve metrics on when it isn't
+// their main port.
+var adminPorts = map[string]string{
+	"grpcserver": "9090",
+}
+
 func StartDockerServer(
 	t testing.TB,
 	port string,
 	binToBuild string,
-) {
+	opts ...ServerOption,
+) DockerServer {
 	t.Helper()
 
 	ctx := context.Background()
+	options := newServerOptions(t, opts)
+	containerPort := nat.Port(port + "/tcp")
+	adminPort := containerPort
+	exposedPorts := []string{string(containerPort)}
+	if p, ok := adminPorts[binToBuild]; ok {
+		adminPort = nat.Port(p + "/tcp")
+		exposedPorts = append(exposedPorts, string(adminPort))
+	}
 	req := testcontainers.ContainerRequest{
 		FromDockerfile: newTCDockerfile(binToBuild),
-		ExposedPorts:   []string{fmt.Sprintf("%s:%s", port, port)},
-		WaitingFor:     wait.ForListeningPort(nat.Port(port)).WithStartupTimeout(startupTimeout),
+		ExposedPorts:   exposedPorts,
+		Env:            options.env,
+		WaitingFor:     readinessCheck(binToBuild, containerPort, options.env),
 	}
 	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
 		ContainerRequest: req,
@@ -39,6 +83,39 @@ func StartDockerServer(
 	t.Cleanup(func() {
 		assert.NoError(t, container.Terminate(ctx))
 	})
+
+	host, err := container.Host(ctx)
+	assert.NoError(t, err)
+	mappedPort, err := container.MappedPort(ctx, containerPort)
+	assert.NoError(t, err)
+	mappedAdminPort, err := container.MappedPort(ctx, adminPort)
+	assert.NoError(t, err)
+
+	// Admin ports serve plain HTTP even when the main port serves TLS.
+	adminURL := baseURL(net.JoinHostPort(host, mappedAdminPort.Port()), adminPort == containerPort && options.env["TLS_CERT"] != "")
+	return DockerServer{
+		Server: Server{
+			Addr:       net.JoinHostPort(host, mappedPort.Port()),
+			MetricsURL: metricsURL(adminURL),
+			RootCAs:    options.rootCAs,
+		},
+		Container: container,
+	}
+}
+
+// readinessChecks override waiting for a binary's port to accept connections
+// with something that knows when it's actually ready to serve.
+var readinessChecks = map[string]func(port nat.Port) wait.Strategy{
+	"grpcserver": waitForGRPCHealth,
+}
+
+// readinessCheck falls back to waiting for the port when the binary serves
+// TLS, which the readiness checks don't speak.
+func readinessCheck(binToBuild string, port nat.Port, env map[string]string) wait.Strategy {
+	if check, ok := readinessChecks[binToBuild]; ok && env["TLS_CERT"] == "" {
+		return check(port)
+	}
+	return wait.ForListeningPort(port).WithStartupTimeout(startupTimeout)
 }
 
 func newTCDockerfile(binToBuild string) testcontainers.FromDockerfile {
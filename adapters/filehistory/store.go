@@ -0,0 +1,90 @@
+// Package filehistory keeps interaction history in a file of JSON lines, so it
+// survives restarts.
+package filehistory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+// Store is a history.Repository backed by an append-only file. Entries are
+// also held in memory, so one Store should own its file.
+type Store struct {
+	mu      sync.RWMutex
+	file    *os.File
+	entries []history.Entry
+}
+
+type line struct {
+	Interaction string    `json:"interaction"`
+	Name        string    `json:"name"`
+	Adapter     string    `json:"adapter"`
+	At          time.Time `json:"at"`
+}
+
+// Open reads the history in path, creating it if it doesn't exist, and appends
+// to it from then on.
+func Open(path string) (*Store, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := read(file)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("reading %s: %w", path, err), file.Close())
+	}
+	return &Store{file: file, entries: entries}, nil
+}
+
+func read(r io.Reader) ([]history.Entry, error) {
+	var entries []history.Entry
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		entries = append(entries, history.Entry(l))
+	}
+	return entries, scanner.Err()
+}
+
+func (s *Store) Record(ctx context.Context, entry history.Entry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b, err := json.Marshal(line(entry))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *Store) List(ctx context.Context, query history.Query) ([]history.Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return history.Newest(s.entries, query), nil
+}
+
+func (s *Store) Close() error {
+	return s.file.Close()
+}
@@ -0,0 +1,49 @@
+package filehistory_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/filehistory"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/history/historytest"
+)
+
+func TestStore(t *testing.T) {
+	historytest.RepositoryContract(t, func(t *testing.T) history.Repository {
+		store, err := filehistory.Open(filepath.Join(t.TempDir(), "history.jsonl"))
+		assert.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, store.Close()) })
+		return store
+	})
+
+	t.Run("remembers entries after being reopened", func(t *testing.T) {
+		var (
+			ctx   = context.Background()
+			path  = filepath.Join(t.TempDir(), "history.jsonl")
+			entry = history.Entry{Interaction: "greet", Name: "Mike", Adapter: "cli", At: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)}
+		)
+		store, err := filehistory.Open(path)
+		assert.NoError(t, err)
+		assert.NoError(t, store.Record(ctx, entry))
+		assert.NoError(t, store.Close())
+
+		store, err = filehistory.Open(path)
+		assert.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, store.Close()) })
+		got, err := store.List(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{entry}, got)
+	})
+
+	t.Run("refuses to open a file that isn't history", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.jsonl")
+		assert.NoError(t, os.WriteFile(path, []byte("{}\nnope\n"), 0o644))
+		_, err := filehistory.Open(path)
+		assert.Error(t, err)
+	})
+}
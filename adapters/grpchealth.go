@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const healthPollInterval = 100 * time.Millisecond
+
+// grpcHealthStrategy waits until the gRPC health service on port reports
+// SERVING, rather than just for the port to accept connections.
+type grpcHealthStrategy struct {
+	port    nat.Port
+	timeout time.Duration
+}
+
+func waitForGRPCHealth(port nat.Port) wait.Strategy {
+	return grpcHealthStrategy{port: port, timeout: startupTimeout}
+}
+
+func (s grpcHealthStrategy) Timeout() *time.Duration {
+	return &s.timeout
+}
+
+func (s grpcHealthStrategy) WaitUntilReady(ctx context.Context, target wait.StrategyTarget) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	host, err := target.Host(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for {
+		if lastErr = s.check(ctx, host, target); lastErr == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for gRPC health on %s: %w", s.port, lastErr)
+		case <-time.After(healthPollInterval):
+		}
+	}
+}
+
+func (s grpcHealthStrategy) check(ctx context.Context, host string, target wait.StrategyTarget) error {
+	mappedPort, err := target.MappedPort(ctx, s.port)
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.NewClient(net.JoinHostPort(host, mappedPort.Port()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		return err
+	}
+	if res.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("health is %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// authenticateUnary refuses RPCs presenting credentials authenticator doesn't
+// accept, and lets the rest through with the user they authenticated as, if
+// any. Clients that present no credentials are the user their certificate
+// names, if they connected with one.
+func authenticateUnary(authenticator auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		user, err := authenticate(ctx, authenticator)
+		if err != nil {
+			return nil, statusFor(err)
+		}
+		return handler(authn.WithUser(ctx, user), req)
+	}
+}
+
+// authenticateStream is authenticateUnary for streaming RPCs.
+func authenticateStream(authenticator auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		user, err := authenticate(stream.Context(), authenticator)
+		if err != nil {
+			return statusFor(err)
+		}
+		return handler(srv, &userStream{ServerStream: stream, ctx: authn.WithUser(stream.Context(), user)})
+	}
+}
+
+func authenticate(ctx context.Context, authenticator auth.Authenticator) (string, error) {
+	user, err := authenticator.Authenticate(authn.FromMetadata(ctx))
+	if err != nil || user != "" {
+		return user, err
+	}
+	return ClientIdentity(ctx), nil
+}
+
+// ClientIdentity is the common name of the verified certificate the client
+// connected with over mutual TLS, or "" if it didn't present one.
+func ClientIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return info.State.VerifiedChains[0][0].Subject.CommonName
+}
+
+// userStream is a grpc.ServerStream whose context carries the user.
+type userStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *userStream) Context() context.Context {
+	return s.ctx
+}
@@ -2,52 +2,184 @@ package grpcserver
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"io"
 	"sync"
 
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/history"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type Driver struct {
 	Addr string
+	// Credentials are presented with every RPC.
+	Credentials auth.Credentials
+	// TLS secures the connection, presenting its client certificate to
+	// servers that ask for one. Nil connects in plaintext.
+	TLS *tls.Config
 
 	connectionOnce sync.Once
 	conn           *grpc.ClientConn
 	client         GreeterClient
 }
 
-func (d *Driver) Greet(name string) (string, error) {
+func (d *Driver) Greet(ctx context.Context, name string) (string, error) {
+	return d.GreetIn(ctx, "", name)
+}
+
+func (d *Driver) GreetIn(ctx context.Context, locale string, name string) (string, error) {
 	client, err := d.getClient()
 	if err != nil {
 		return "", err
 	}
 
-	greeting, err := client.Greet(context.Background(), &GreetRequest{
-		Name: name,
+	greeting, err := client.Greet(ctx, &GreetRequest{
+		Name:   name,
+		Locale: locale,
 	})
 	if err != nil {
-		return "", err
+		return "", errorFrom(err)
 	}
 
 	return greeting.Message, nil
 }
 
-func (d *Driver) Curse(name string) (string, error) {
+func (d *Driver) Curse(ctx context.Context, name string) (string, error) {
+	return d.CurseIn(ctx, "", name)
+}
+
+func (d *Driver) CurseIn(ctx context.Context, locale string, name string) (string, error) {
 	client, err := d.getClient()
 	if err != nil {
 		return "", err
 	}
 
-	greeting, err := client.Curse(context.Background(), &CurseRequest{
-		Name: name,
+	greeting, err := client.Curse(ctx, &CurseRequest{
+		Name:   name,
+		Locale: locale,
 	})
 	if err != nil {
-		return "", err
+		return "", errorFrom(err)
 	}
 
 	return greeting.Message, nil
 }
 
+func (d *Driver) History(ctx context.Context, query history.Query) ([]history.Entry, error) {
+	client, err := d.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.ListHistory(ctx, &ListHistoryRequest{
+		Name:        query.Name,
+		Interaction: query.Interaction,
+		Limit:       int32(query.Limit),
+	})
+	if err != nil {
+		return nil, errorFrom(err)
+	}
+
+	entries := make([]history.Entry, 0, len(reply.Entries))
+	for _, entry := range reply.Entries {
+		entries = append(entries, history.Entry{
+			Interaction: entry.Interaction,
+			Name:        entry.Name,
+			Adapter:     entry.Adapter,
+			At:          entry.At.AsTime(),
+		})
+	}
+	return entries, nil
+}
+
+func (d *Driver) Interact(ctx context.Context, interaction string, locale string, name string) (string, error) {
+	client, err := d.getClient()
+	if err != nil {
+		return "", err
+	}
+
+	reply, err := client.Interact(ctx, &InteractRequest{
+		Interaction: interaction,
+		Name:        name,
+		Locale:      locale,
+	})
+	if err != nil {
+		return "", errorFrom(err)
+	}
+
+	return reply.Message, nil
+}
+
+func (d *Driver) GreetMany(ctx context.Context, names []string) ([]string, error) {
+	return d.GreetManyIn(ctx, "", names)
+}
+
+func (d *Driver) GreetManyIn(ctx context.Context, locale string, names []string) ([]string, error) {
+	client, err := d.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.GreetMany(ctx, &GreetManyRequest{Names: names, Locale: locale})
+	if err != nil {
+		return nil, errorFrom(err)
+	}
+
+	var greetings []string
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return greetings, nil
+		}
+		if err != nil {
+			return greetings, errorFrom(err)
+		}
+		greetings = append(greetings, reply.Message)
+	}
+}
+
+// Converse greets names over a single Converse stream, sending each name only
+// once the previous one has been replied to.
+func (d *Driver) Converse(ctx context.Context, names []string) ([]string, error) {
+	client, err := d.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := client.Converse(ctx)
+	if err != nil {
+		return nil, errorFrom(err)
+	}
+
+	var greetings []string
+	for _, name := range names {
+		if err := stream.Send(&GreetRequest{Name: name}); err != nil && !errors.Is(err, io.EOF) {
+			return greetings, err
+		}
+		// A Send that hit io.EOF means the server has ended the stream, and
+		// Recv reports why.
+		reply, err := stream.Recv()
+		if err != nil {
+			return greetings, errorFrom(err)
+		}
+		greetings = append(greetings, reply.Message)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		return greetings, err
+	}
+	if _, err := stream.Recv(); !errors.Is(err, io.EOF) {
+		return greetings, errorFrom(err)
+	}
+	return greetings, nil
+}
+
 func (d *Driver) Close() {
 	if d.conn != nil {
 		d.conn.Close()
@@ -57,8 +189,28 @@ func (d *Driver) Close() {
 func (d *Driver) getClient() (GreeterClient, error) {
 	var err error
 	d.connectionOnce.Do(func() {
-		d.conn, err = grpc.NewClient(d.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		transport := insecure.NewCredentials()
+		if d.TLS != nil {
+			transport = credentials.NewTLS(d.TLS)
+		}
+		d.conn, err = grpc.NewClient(d.Addr,
+			grpc.WithTransportCredentials(transport),
+			grpc.WithPerRPCCredentials(perRPCCredentials(d.Credentials)),
+			telemetry.TraceGRPCClient(),
+		)
 		d.client = NewGreeterClient(d.conn)
 	})
 	return d.client, err
 }
+
+// perRPCCredentials sends credentials as metadata. They're sent in the clear
+// over insecure connections, so only use real ones over TLS.
+type perRPCCredentials auth.Credentials
+
+func (c perRPCCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return authn.Metadata(auth.Credentials(c)), nil
+}
+
+func (c perRPCCredentials) RequireTransportSecurity() bool {
+	return false
+}
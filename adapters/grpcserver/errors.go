@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"errors"
+
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is the ErrorInfo domain domain errors are reported under.
+const errorDomain = "interactions.go-specs-greet"
+
+// statusFor maps domain errors onto gRPC codes, attaching the domain error's
+// code as ErrorInfo so the Driver can rebuild it. Anything else is an internal
+// error whose detail is not the client's business.
+func statusFor(err error) error {
+	var domainErr *interactions.Error
+	if !errors.As(err, &domainErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code := codes.Internal
+	switch domainErr.Kind {
+	case interactions.KindInvalid:
+		code = codes.InvalidArgument
+	case interactions.KindNotFound:
+		code = codes.NotFound
+	case interactions.KindThrottled:
+		code = codes.ResourceExhausted
+	case interactions.KindRefused:
+		// PermissionDenied would suggest different credentials might help,
+		// and FailedPrecondition that asking again later might.
+		code = codes.InvalidArgument
+	case interactions.KindUnauthenticated:
+		code = codes.Unauthenticated
+	}
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: domainErr.Code,
+		Domain: errorDomain,
+	}}
+	var throttled *interactions.ThrottledError
+	if errors.As(err, &throttled) {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(throttled.RetryAfter)})
+	}
+	st, detailErr := status.New(code, domainErr.Detail).WithDetails(details...)
+	if detailErr != nil {
+		return status.Error(code, domainErr.Detail)
+	}
+	return st.Err()
+}
+
+// errorFrom rebuilds the domain error a status carries, returning err
+// untouched when it doesn't carry one.
+func errorFrom(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	var (
+		domainErr *interactions.Error
+		retry     *errdetails.RetryInfo
+	)
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if known, ok := interactions.LookupError(detail.Reason); ok && detail.Domain == errorDomain {
+				domainErr = known
+			}
+		case *errdetails.RetryInfo:
+			retry = detail
+		}
+	}
+	switch {
+	case domainErr == nil:
+		return err
+	case domainErr == interactions.ErrTooManyRequests && retry != nil:
+		return &interactions.ThrottledError{RetryAfter: retry.RetryDelay.AsDuration()}
+	default:
+		return domainErr
+	}
+}
@@ -24,6 +24,17 @@ const _ = grpc.SupportPackageIsVersion7
 type GreeterClient interface {
 	Greet(ctx context.Context, in *GreetRequest, opts ...grpc.CallOption) (*GreetReply, error)
 	Curse(ctx context.Context, in *CurseRequest, opts ...grpc.CallOption) (*CurseReply, error)
+	// Interact replies with any interaction in the server's registry, such as
+	// "greet", "curse", "welcome" or "farewell".
+	Interact(ctx context.Context, in *InteractRequest, opts ...grpc.CallOption) (*InteractReply, error)
+	// GreetMany streams a greeting for each name, in order. It stops with an
+	// error at the first invalid name.
+	GreetMany(ctx context.Context, in *GreetManyRequest, opts ...grpc.CallOption) (Greeter_GreetManyClient, error)
+	// Converse replies to each greeting request as it arrives. It stops with an
+	// error at the first invalid name.
+	Converse(ctx context.Context, opts ...grpc.CallOption) (Greeter_ConverseClient, error)
+	// ListHistory returns past interactions, most recent first.
+	ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryReply, error)
 }
 
 type greeterClient struct {
@@ -52,12 +63,104 @@ func (c *greeterClient) Curse(ctx context.Context, in *CurseRequest, opts ...grp
 	return out, nil
 }
 
+func (c *greeterClient) Interact(ctx context.Context, in *InteractRequest, opts ...grpc.CallOption) (*InteractReply, error) {
+	out := new(InteractReply)
+	err := c.cc.Invoke(ctx, "/grpcserver.Greeter/Interact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GreetMany(ctx context.Context, in *GreetManyRequest, opts ...grpc.CallOption) (Greeter_GreetManyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Greeter_ServiceDesc.Streams[0], "/grpcserver.Greeter/GreetMany", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &greeterGreetManyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Greeter_GreetManyClient interface {
+	Recv() (*GreetReply, error)
+	grpc.ClientStream
+}
+
+type greeterGreetManyClient struct {
+	grpc.ClientStream
+}
+
+func (x *greeterGreetManyClient) Recv() (*GreetReply, error) {
+	m := new(GreetReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *greeterClient) Converse(ctx context.Context, opts ...grpc.CallOption) (Greeter_ConverseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Greeter_ServiceDesc.Streams[1], "/grpcserver.Greeter/Converse", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &greeterConverseClient{stream}
+	return x, nil
+}
+
+type Greeter_ConverseClient interface {
+	Send(*GreetRequest) error
+	Recv() (*GreetReply, error)
+	grpc.ClientStream
+}
+
+type greeterConverseClient struct {
+	grpc.ClientStream
+}
+
+func (x *greeterConverseClient) Send(m *GreetRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *greeterConverseClient) Recv() (*GreetReply, error) {
+	m := new(GreetReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *greeterClient) ListHistory(ctx context.Context, in *ListHistoryRequest, opts ...grpc.CallOption) (*ListHistoryReply, error) {
+	out := new(ListHistoryReply)
+	err := c.cc.Invoke(ctx, "/grpcserver.Greeter/ListHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GreeterServer is the server API for Greeter service.
 // All implementations must embed UnimplementedGreeterServer
 // for forward compatibility
 type GreeterServer interface {
 	Greet(context.Context, *GreetRequest) (*GreetReply, error)
 	Curse(context.Context, *CurseRequest) (*CurseReply, error)
+	// Interact replies with any interaction in the server's registry, such as
+	// "greet", "curse", "welcome" or "farewell".
+	Interact(context.Context, *InteractRequest) (*InteractReply, error)
+	// GreetMany streams a greeting for each name, in order. It stops with an
+	// error at the first invalid name.
+	GreetMany(*GreetManyRequest, Greeter_GreetManyServer) error
+	// Converse replies to each greeting request as it arrives. It stops with an
+	// error at the first invalid name.
+	Converse(Greeter_ConverseServer) error
+	// ListHistory returns past interactions, most recent first.
+	ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryReply, error)
 	mustEmbedUnimplementedGreeterServer()
 }
 
@@ -71,6 +174,18 @@ func (UnimplementedGreeterServer) Greet(context.Context, *GreetRequest) (*GreetR
 func (UnimplementedGreeterServer) Curse(context.Context, *CurseRequest) (*CurseReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Curse not implemented")
 }
+func (UnimplementedGreeterServer) Interact(context.Context, *InteractRequest) (*InteractReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Interact not implemented")
+}
+func (UnimplementedGreeterServer) GreetMany(*GreetManyRequest, Greeter_GreetManyServer) error {
+	return status.Errorf(codes.Unimplemented, "method GreetMany not implemented")
+}
+func (UnimplementedGreeterServer) Converse(Greeter_ConverseServer) error {
+	return status.Errorf(codes.Unimplemented, "method Converse not implemented")
+}
+func (UnimplementedGreeterServer) ListHistory(context.Context, *ListHistoryRequest) (*ListHistoryReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHistory not implemented")
+}
 func (UnimplementedGreeterServer) mustEmbedUnimplementedGreeterServer() {}
 
 // UnsafeGreeterServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +235,89 @@ func _Greeter_Curse_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_Interact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InteractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).Interact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcserver.Greeter/Interact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).Interact(ctx, req.(*InteractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GreetMany_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GreetManyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GreeterServer).GreetMany(m, &greeterGreetManyServer{stream})
+}
+
+type Greeter_GreetManyServer interface {
+	Send(*GreetReply) error
+	grpc.ServerStream
+}
+
+type greeterGreetManyServer struct {
+	grpc.ServerStream
+}
+
+func (x *greeterGreetManyServer) Send(m *GreetReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Greeter_Converse_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GreeterServer).Converse(&greeterConverseServer{stream})
+}
+
+type Greeter_ConverseServer interface {
+	Send(*GreetReply) error
+	Recv() (*GreetRequest, error)
+	grpc.ServerStream
+}
+
+type greeterConverseServer struct {
+	grpc.ServerStream
+}
+
+func (x *greeterConverseServer) Send(m *GreetReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *greeterConverseServer) Recv() (*GreetRequest, error) {
+	m := new(GreetRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Greeter_ListHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).ListHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpcserver.Greeter/ListHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).ListHistory(ctx, req.(*ListHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Greeter_ServiceDesc is the grpc.ServiceDesc for Greeter service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,7 +333,27 @@ var Greeter_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Curse",
 			Handler:    _Greeter_Curse_Handler,
 		},
+		{
+			MethodName: "Interact",
+			Handler:    _Greeter_Interact_Handler,
+		},
+		{
+			MethodName: "ListHistory",
+			Handler:    _Greeter_ListHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GreetMany",
+			Handler:       _Greeter_GreetMany_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Converse",
+			Handler:       _Greeter_Converse_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "greet.proto",
 }
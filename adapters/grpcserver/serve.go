@@ -0,0 +1,71 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server is a gRPC server offering the Greeter service alongside the standard
+// health service and server reflection, so grpcurl can list and call it.
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+// NewServer serves the Greeter service backed by service to callers
+// authenticator accepts. Interceptors in opts run before authentication.
+func NewServer(service *greeter.Service, authenticator auth.Authenticator, opts ...grpc.ServerOption) *Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(authenticateUnary(authenticator)),
+		grpc.ChainStreamInterceptor(authenticateStream(authenticator)),
+	)
+	s := &Server{
+		Server: grpc.NewServer(opts...),
+		health: health.NewServer(),
+	}
+	RegisterGreeterServer(s.Server, &GreetServer{Service: service})
+	healthpb.RegisterHealthServer(s.Server, s.health)
+	reflection.Register(s.Server)
+
+	s.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// Serve reports healthy and serves lis until Shutdown or Stop.
+func (s *Server) Serve(lis net.Listener) error {
+	s.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+	return s.Server.Serve(lis)
+}
+
+// Shutdown reports unhealthy, so clients stop sending new work, then waits
+// for in-flight RPCs to finish. If ctx is done first the remaining RPCs are
+// cancelled and ctx's error is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		return ctx.Err()
+	}
+}
+
+func (s *Server) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.health.SetServingStatus("", status)
+	s.health.SetServingStatus(Greeter_ServiceDesc.ServiceName, status)
+}
@@ -2,18 +2,153 @@ package grpcserver
 
 import (
 	"context"
+	"errors"
+	"io"
+	"net"
 
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
 	"github.com/quii/go-specs-greet/domain/interactions"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// AdapterName is what this adapter is called in the history.
+const AdapterName = "grpcserver"
+
 type GreetServer struct {
 	UnimplementedGreeterServer
+	Service *greeter.Service
+}
+
+func (g GreetServer) interact(ctx context.Context, interaction interactions.Interaction, locale language.Tag, name string) (string, error) {
+	return g.Service.Interact(ctx, greeter.Call{
+		Interaction: interaction,
+		Locale:      locale,
+		Name:        name,
+		Caller:      callerOf(ctx),
+		User:        authn.UserFrom(ctx),
+		Adapter:     AdapterName,
+	})
+}
+
+// callerOf identifies who made an RPC by the IP address of its peer.
+func callerOf(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (g GreetServer) Curse(ctx context.Context, request *CurseRequest) (*CurseReply, error) {
+	reply, err := g.Interact(ctx, &InteractRequest{
+		Interaction: interactions.Cursing.Name,
+		Name:        request.Name,
+		Locale:      request.Locale,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CurseReply{Message: reply.Message}, nil
+}
+
+func (g GreetServer) Greet(ctx context.Context, request *GreetRequest) (*GreetReply, error) {
+	reply, err := g.Interact(ctx, &InteractRequest{
+		Interaction: interactions.Greeting.Name,
+		Name:        request.Name,
+		Locale:      request.Locale,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &GreetReply{Message: reply.Message}, nil
+}
+
+func (g GreetServer) Interact(ctx context.Context, request *InteractRequest) (*InteractReply, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	interaction, ok := interactions.DefaultRegistry.Lookup(request.Interaction)
+	if !ok {
+		return nil, statusFor(interactions.ErrUnknownInteraction)
+	}
+	telemetry.SetInteraction(ctx, interaction.Name)
+	locale := interactions.ParseLocale(request.Locale)
+	message, err := g.interact(ctx, interaction, locale, request.Name)
+	if err != nil {
+		return nil, statusFor(err)
+	}
+	return &InteractReply{
+		Message:     message,
+		Interaction: request.Interaction,
+		Locale:      locale.String(),
+	}, nil
+}
+
+func (g GreetServer) GreetMany(request *GreetManyRequest, stream Greeter_GreetManyServer) error {
+	telemetry.SetInteraction(stream.Context(), interactions.Greeting.Name)
+	locale := interactions.ParseLocale(request.Locale)
+	for _, name := range request.Names {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		message, err := g.interact(stream.Context(), interactions.Greeting, locale, name)
+		if err != nil {
+			return statusFor(err)
+		}
+		if err := stream.Send(&GreetReply{Message: message}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (g GreetServer) Curse(_ context.Context, request *CurseRequest) (*CurseReply, error) {
-	return &CurseReply{Message: interactions.Curse(request.Name)}, nil
+func (g GreetServer) Converse(stream Greeter_ConverseServer) error {
+	telemetry.SetInteraction(stream.Context(), interactions.Greeting.Name)
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		message, err := g.interact(stream.Context(), interactions.Greeting, interactions.ParseLocale(request.Locale), request.Name)
+		if err != nil {
+			return statusFor(err)
+		}
+		if err := stream.Send(&GreetReply{Message: message}); err != nil {
+			return err
+		}
+	}
 }
 
-func (g GreetServer) Greet(_ context.Context, request *GreetRequest) (*GreetReply, error) {
-	return &GreetReply{Message: interactions.Greet(request.Name)}, nil
+func (g GreetServer) ListHistory(ctx context.Context, request *ListHistoryRequest) (*ListHistoryReply, error) {
+	entries, err := g.Service.ListHistory(ctx, history.Query{
+		Name:        request.Name,
+		Interaction: request.Interaction,
+		Limit:       int(request.Limit),
+	})
+	if err != nil {
+		return nil, statusFor(err)
+	}
+	reply := &ListHistoryReply{Entries: make([]*HistoryEntry, 0, len(entries))}
+	for _, entry := range entries {
+		reply.Entries = append(reply.Entries, &HistoryEntry{
+			Interaction: entry.Interaction,
+			Name:        entry.Name,
+			Adapter:     entry.Adapter,
+			At:          timestamppb.New(entry.At),
+		})
+	}
+	return reply, nil
 }
@@ -1,32 +1,187 @@
 package httpserver
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
 type Driver struct {
 	BaseURL string
 	Client  *http.Client
+	// Credentials are presented with every request.
+	Credentials auth.Credentials
+}
+
+// ClientTrusting returns a client for a Driver whose server's certificate
+// chains to rootCAs, such as one signed by a development CA, rather than to
+// the system's roots. It speaks HTTP/2 when the server does.
+func ClientTrusting(rootCAs *x509.CertPool, timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	transport.ForceAttemptHTTP2 = true
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
+
+func (d Driver) Curse(ctx context.Context, name string) (string, error) {
+	return d.CurseIn(ctx, "", name)
+}
+
+func (d Driver) Greet(ctx context.Context, name string) (string, error) {
+	return d.GreetIn(ctx, "", name)
 }
 
-func (d Driver) Curse(name string) (string, error) {
-	return d.getAndReadFrom(cursePath, name)
+func (d Driver) CurseIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Cursing.Name, locale, name)
 }
 
-func (d Driver) Greet(name string) (string, error) {
-	return d.getAndReadFrom(greetPath, name)
+func (d Driver) GreetIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Greeting.Name, locale, name)
 }
 
-func (d Driver) getAndReadFrom(path string, name string) (string, error) {
-	res, err := d.Client.Get(d.BaseURL + path + "?name=" + name)
+func (d Driver) Interact(ctx context.Context, interaction string, locale string, name string) (string, error) {
+	return d.getAndReadFrom(ctx, pathFor(interaction), locale, name)
+}
+
+func (d Driver) getAndReadFrom(ctx context.Context, path string, locale string, name string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.BaseURL+path+"?"+url.Values{"name": {name}}.Encode(), nil)
 	if err != nil {
 		return "", err
 	}
-	defer res.Body.Close()
-	greeting, err := io.ReadAll(res.Body)
+	req.Header.Set("Accept", mediaTypeJSON)
+	telemetry.InjectHTTP(ctx, req.Header)
+	authn.SetHeader(req.Header, d.Credentials)
+	if locale != "" {
+		req.Header.Set("Accept-Language", locale)
+	}
+	res, err := d.Client.Do(req)
 	if err != nil {
 		return "", err
 	}
-	return string(greeting), nil
+	defer res.Body.Close()
+
+	if err := checkStatus(res); err != nil {
+		return "", err
+	}
+
+	var reply Reply
+	if err := json.NewDecoder(res.Body).Decode(&reply); err != nil {
+		return "", err
+	}
+	return reply.Message, nil
+}
+
+func (d Driver) History(ctx context.Context, query history.Query) ([]history.Entry, error) {
+	values := url.Values{}
+	if query.Name != "" {
+		values.Set("name", query.Name)
+	}
+	if query.Interaction != "" {
+		values.Set("interaction", query.Interaction)
+	}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.BaseURL+historyPath+"?"+values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaTypeJSON)
+	telemetry.InjectHTTP(ctx, req.Header)
+	authn.SetHeader(req.Header, d.Credentials)
+	res, err := d.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if err := checkStatus(res); err != nil {
+		return nil, err
+	}
+	var body History
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	entries := make([]history.Entry, 0, len(body.Entries))
+	for _, entry := range body.Entries {
+		entries = append(entries, history.Entry(entry))
+	}
+	return entries, nil
+}
+
+// maxErrorBodyBytes is as much of an unsuccessful response's body as a
+// StatusError keeps.
+const maxErrorBodyBytes = 4 << 10
+
+// StatusError is an unsuccessful response.
+type StatusError struct {
+	StatusCode int
+	// Body is the start of the response's body.
+	Body string
+	// Err is the error the body describes, if it was a problem: a domain error
+	// when the server said which, otherwise the *Problem itself.
+	Err error
+}
+
+func (e *StatusError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	if e.Body == "" {
+		return fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// checkStatus returns a *StatusError when res isn't a success, and nil when
+// it is.
+func checkStatus(res *http.Response) error {
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxErrorBodyBytes))
+	if err != nil {
+		return err
+	}
+	statusErr := &StatusError{StatusCode: res.StatusCode, Body: strings.TrimSpace(string(body))}
+	if mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type")); mediaType == mediaTypeProblem {
+		statusErr.Err = errorFrom(res, body)
+	}
+	return statusErr
+}
+
+// errorFrom returns the error a problem body describes, rebuilt as a domain
+// error when it is one.
+func errorFrom(res *http.Response, body []byte) error {
+	var problem Problem
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return fmt.Errorf("malformed problem: %w", err)
+	}
+	domainErr, ok := interactions.LookupError(problem.Code)
+	if !ok {
+		return &problem
+	}
+	if retryAfter, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && domainErr == interactions.ErrTooManyRequests {
+		return &interactions.ThrottledError{RetryAfter: time.Duration(retryAfter) * time.Second}
+	}
+	return domainErr
 }
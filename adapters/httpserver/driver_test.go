@@ -0,0 +1,42 @@
+package httpserver_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+)
+
+func TestDriver(t *testing.T) {
+	t.Run("abandons calls when the context is cancelled", func(t *testing.T) {
+		arrived := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			close(arrived)
+			<-r.Context().Done()
+		}))
+		t.Cleanup(server.Close)
+		driver := httpserver.Driver{BaseURL: server.URL, Client: server.Client()}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-arrived
+			cancel()
+		}()
+		done := make(chan error, 1)
+		go func() {
+			_, err := driver.Greet(ctx, "Mike")
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			assert.IsError(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("Greet didn't return after its context was cancelled")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// FuzzHandler checks the handler never fails with an internal error, always
+// describes its failures as problems to clients that don't accept plain
+// text, and greets with the name as the domain
+// normalises it. The seed corpus is in testdata/fuzz.
+func FuzzHandler(f *testing.F) {
+	f.Add("greet", "Mike", "application/json", "en", "")
+	f.Add("curse", "Chris", "application/json", "fr", "fuzz-api-key")
+
+	handler := httpserver.NewHandler(
+		greeter.NewService(history.NewInMemory(), nil),
+		auth.APIKeys{"fuzz-api-key": "fuzzer"},
+	)
+	f.Fuzz(func(t *testing.T, interaction, name, accept, acceptLanguage, apiKey string) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.URL.Path = "/" + interaction
+		req.URL.RawQuery = url.Values{"name": {name}}.Encode()
+		req.Header.Set("Accept", accept)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		if res.Code == http.StatusInternalServerError {
+			t.Fatalf("GET %s failed: %s", req.URL, res.Body)
+		}
+		mediaType, _, _ := mime.ParseMediaType(res.Header().Get("Content-Type"))
+		switch {
+		case res.Code == http.StatusOK && mediaType == "application/json":
+			var reply httpserver.Reply
+			if err := json.Unmarshal(res.Body.Bytes(), &reply); err != nil {
+				t.Fatalf("GET %s replied with malformed JSON: %v", req.URL, err)
+			}
+			if want, err := interactions.NormaliseName(name); err == nil && !strings.Contains(reply.Message, want) {
+				t.Fatalf("GET %s = %q, which doesn't contain %q", req.URL, reply.Message, want)
+			}
+		case mediaType == "application/problem+json":
+			var problem httpserver.Problem
+			if err := json.Unmarshal(res.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("GET %s replied with a malformed problem: %v", req.URL, err)
+			}
+			if problem.Status != res.Code {
+				t.Fatalf("GET %s sent a %d problem with status %d", req.URL, problem.Status, res.Code)
+			}
+		case res.Code >= http.StatusBadRequest && !acceptsText(accept):
+			t.Fatalf("GET %s failed with %d as %q, not as a problem", req.URL, res.Code, mediaType)
+		}
+	})
+}
+
+// textRanges are the media ranges that match text/plain, by how specifically.
+var textRanges = map[string]int{"text/plain": 2, "text/*": 1, "*/*": 0}
+
+// acceptsText reports whether accept ranks plain text above nothing, as the
+// most specific range matching it does, so the handler may describe
+// failures in plain text rather than as problems.
+func acceptsText(accept string) bool {
+	if strings.TrimSpace(accept) == "" {
+		return true
+	}
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		s, matches := textRanges[mediaType]
+		if !matches || s <= specificity {
+			continue
+		}
+		rangeQ := 1.0
+		if raw, ok := params["q"]; ok {
+			if rangeQ, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+		q, specificity = rangeQ, s
+	}
+	return q > 0
+}
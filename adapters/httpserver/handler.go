@@ -1,27 +1,241 @@
 package httpserver
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"mime"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
 	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
-const (
-	greetPath = "/greet"
-	cursePath = "/curse"
-)
+const maxBodyBytes = 64 << 10
+
+// Request is the JSON body accepted by POST requests. Locale, when set, takes
+// precedence over the Accept-Language header.
+type Request struct {
+	Name   string `json:"name"`
+	Locale string `json:"locale,omitempty"`
+}
 
-func NewHandler() http.Handler {
+// Reply is the body sent to clients that accept application/json.
+type Reply struct {
+	Message     string `json:"message"`
+	Interaction string `json:"interaction"`
+	Locale      string `json:"locale"`
+}
+
+// Problem is an RFC 9457 problem detail, sent as application/problem+json to
+// clients that accept JSON.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// Code identifies the domain error, see interactions.LookupError.
+	Code string `json:"code,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return fmt.Sprintf("%d %s", p.Status, p.Title)
+	}
+	return fmt.Sprintf("%d %s: %s", p.Status, p.Title, p.Detail)
+}
+
+func newProblem(status int, detail string) *Problem {
+	return &Problem{Type: "about:blank", Title: http.StatusText(status), Status: status, Detail: detail}
+}
+
+// AdapterName is what this adapter is called in the history.
+const AdapterName = "httpserver"
+
+// NewHandler serves every interaction in the default registry at /{name},
+// and their history at /history, to callers authenticator accepts.
+func NewHandler(service *greeter.Service, authenticator auth.Authenticator) http.Handler {
 	mux := http.NewServeMux()
-	mux.HandleFunc(greetPath, replyWith(interactions.Greet))
-	mux.HandleFunc(cursePath, replyWith(interactions.Curse))
-	return mux
+	for _, interaction := range interactions.DefaultRegistry.All() {
+		mux.HandleFunc(pathFor(interaction.Name), replyWith(service, interaction))
+	}
+	mux.HandleFunc(historyPath, listHistory(service))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, problemFor(interactions.ErrUnknownInteraction))
+	})
+	return authenticate(authenticator, mux)
+}
+
+// authenticate refuses requests presenting credentials authenticator doesn't
+// accept, and lets the rest through with the user they authenticated as, if
+// any.
+func authenticate(authenticator auth.Authenticator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := authenticator.Authenticate(authn.FromHeader(r.Header))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(authn.WithUser(r.Context(), user)))
+	})
 }
 
-func replyWith(f func(name string) (interaction string)) http.HandlerFunc {
+func pathFor(interaction string) string {
+	return "/" + interaction
+}
+
+func replyWith(service *greeter.Service, interaction interactions.Interaction) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		name := r.URL.Query().Get("name")
-		fmt.Fprint(w, f(name))
+		if r.Context().Err() != nil {
+			return
+		}
+		telemetry.SetInteraction(r.Context(), interaction.Name)
+
+		req, problem := readRequest(w, r)
+		if problem != nil {
+			writeProblem(w, r, problem)
+			return
+		}
+
+		format := negotiate(r.Header.Get("Accept"), mediaTypeText, mediaTypeJSON)
+		if format == "" {
+			writeProblem(w, r, newProblem(http.StatusNotAcceptable, "supported types are "+mediaTypeText+" and "+mediaTypeJSON))
+			return
+		}
+
+		locale := interactions.ParseLocale(r.Header.Get("Accept-Language"))
+		if req.Locale != "" {
+			locale = interactions.ParseLocale(req.Locale)
+		}
+		message, err := service.Interact(r.Context(), greeter.Call{
+			Interaction: interaction,
+			Locale:      locale,
+			Name:        req.Name,
+			Caller:      callerOf(r),
+			User:        authn.UserFrom(r.Context()),
+			Adapter:     AdapterName,
+		})
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		reply := Reply{
+			Message:     message,
+			Interaction: interaction.Name,
+			Locale:      locale.String(),
+		}
+
+		w.Header().Set("Content-Language", reply.Locale)
+		w.Header().Add("Vary", "Accept, Accept-Language")
+		if format == mediaTypeJSON {
+			w.Header().Set("Content-Type", mediaTypeJSON)
+			_ = json.NewEncoder(w).Encode(reply)
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeText+"; charset=utf-8")
+		fmt.Fprint(w, reply.Message)
+	}
+}
+
+// readRequest takes the name from the query string for GET requests and from
+// a JSON body for POST requests.
+func readRequest(w http.ResponseWriter, r *http.Request) (Request, *Problem) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return Request{Name: r.URL.Query().Get("name")}, nil
+	case http.MethodPost:
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != mediaTypeJSON {
+			return Request{}, newProblem(http.StatusUnsupportedMediaType, "POST bodies must be "+mediaTypeJSON)
+		}
+		var req Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return Request{}, newProblem(http.StatusRequestEntityTooLarge, err.Error())
+			}
+			return Request{}, newProblem(http.StatusBadRequest, "malformed JSON body: "+err.Error())
+		}
+		return req, nil
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		return Request{}, newProblem(http.StatusMethodNotAllowed, "")
+	}
+}
+
+// problemFor maps domain errors onto HTTP statuses. Anything else is an
+// internal error whose detail is not the client's business.
+func problemFor(err error) *Problem {
+	var domainErr *interactions.Error
+	if !errors.As(err, &domainErr) {
+		return newProblem(http.StatusInternalServerError, "")
+	}
+
+	status := http.StatusInternalServerError
+	switch domainErr.Kind {
+	case interactions.KindInvalid:
+		status = http.StatusBadRequest
+	case interactions.KindNotFound:
+		status = http.StatusNotFound
+	case interactions.KindThrottled:
+		status = http.StatusTooManyRequests
+	case interactions.KindRefused:
+		status = http.StatusUnprocessableEntity
+	case interactions.KindUnauthenticated:
+		status = http.StatusUnauthorized
+	}
+	problem := newProblem(status, domainErr.Detail)
+	problem.Code = domainErr.Code
+	return problem
+}
+
+// writeError sends err as a problem, telling throttled callers when they may
+// try again and unauthenticated ones how to authenticate.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		throttled *interactions.ThrottledError
+		domainErr *interactions.Error
+	)
+	if errors.As(err, &throttled) {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(throttled.RetryAfter)))
+	}
+	if errors.As(err, &domainErr) && domainErr.Kind == interactions.KindUnauthenticated {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="greeter"`)
+	}
+	writeProblem(w, r, problemFor(err))
+}
+
+// retryAfterSeconds rounds up, so callers that wait as long as they're told
+// aren't refused again.
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// callerOf identifies who sent r by their IP address.
+func callerOf(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// writeProblem sends problem as application/problem+json to clients that
+// accept JSON, and as plain text to everyone else.
+func writeProblem(w http.ResponseWriter, r *http.Request, problem *Problem) {
+	if negotiate(r.Header.Get("Accept"), mediaTypeText, mediaTypeJSON, mediaTypeProblem) == mediaTypeText {
+		http.Error(w, problem.Error(), problem.Status)
+		return
 	}
+	w.Header().Set("Content-Type", mediaTypeProblem)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
 }
@@ -0,0 +1,129 @@
+package httpserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+func TestHandler(t *testing.T) {
+	handler := httpserver.NewHandler(greeter.NewService(history.NewInMemory(), nil), auth.APIKeys{})
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, r)
+		return res
+	}
+	get := func(target string, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+		return serve(r)
+	}
+	post := func(contentType string, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/greet", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		r.Header.Set("Accept", "application/json")
+		return serve(r)
+	}
+
+	t.Run("replies in plain text by default", func(t *testing.T) {
+		res := get("/greet?name=Mike", "")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", res.Header().Get("Content-Type"))
+		assert.Equal(t, "Hello, Mike", res.Body.String())
+	})
+
+	t.Run("replies with JSON to clients that prefer it", func(t *testing.T) {
+		res := get("/greet?name=Mike", "text/plain;q=0.5, application/json")
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+		assert.Equal(t, httpserver.Reply{Message: "Hello, Mike", Interaction: "greet", Locale: "en"}, decode[httpserver.Reply](t, res))
+	})
+
+	t.Run("ranks what clients accept by quality and specificity", func(t *testing.T) {
+		for accept, want := range map[string]string{
+			"*/*":                            "text/plain; charset=utf-8",
+			"application/*":                  "application/json",
+			"application/json;q=0.9, */*":    "text/plain; charset=utf-8",
+			"text/plain;q=0.1, */*":          "application/json",
+			"text/*;q=0.5, application/json": "application/json",
+			"application/json, text/plain":   "text/plain; charset=utf-8",
+		} {
+			res := get("/greet?name=Mike", accept)
+			assert.Equal(t, http.StatusOK, res.Code, accept)
+			assert.Equal(t, want, res.Header().Get("Content-Type"), accept)
+		}
+	})
+
+	t.Run("refuses clients that accept neither", func(t *testing.T) {
+		res := get("/greet?name=Mike", "image/png")
+		assert.Equal(t, http.StatusNotAcceptable, res.Code)
+	})
+
+	t.Run("describes errors as problems to clients that accept JSON", func(t *testing.T) {
+		res := get("/greet?name=Mi%C2%85ke", "application/json")
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, "application/problem+json", res.Header().Get("Content-Type"))
+		problem := decode[httpserver.Problem](t, res)
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "Bad Request", problem.Title)
+		assert.NotZero(t, problem.Code)
+	})
+
+	t.Run("describes errors in plain text to clients that only accept text", func(t *testing.T) {
+		res := get("/serenade", "text/plain")
+		assert.Equal(t, http.StatusNotFound, res.Code)
+		assert.True(t, strings.HasPrefix(res.Header().Get("Content-Type"), "text/plain"), res.Header().Get("Content-Type"))
+		assert.Contains(t, res.Body.String(), "404 Not Found")
+	})
+
+	t.Run("reads the name and locale from a JSON body", func(t *testing.T) {
+		res := post("application/json", `{"name":"Mike","locale":"de"}`)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, httpserver.Reply{Message: "Hallo, Mike", Interaction: "greet", Locale: "de"}, decode[httpserver.Reply](t, res))
+	})
+
+	t.Run("refuses malformed JSON bodies", func(t *testing.T) {
+		res := post("application/json", `{"name":`)
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusBadRequest, decode[httpserver.Problem](t, res).Status)
+	})
+
+	t.Run("refuses form bodies", func(t *testing.T) {
+		res := post("application/x-www-form-urlencoded", url.Values{"name": {"Mike"}}.Encode())
+		assert.Equal(t, http.StatusUnsupportedMediaType, res.Code)
+		assert.Equal(t, http.StatusUnsupportedMediaType, decode[httpserver.Problem](t, res).Status)
+	})
+
+	t.Run("refuses bodies that are too large", func(t *testing.T) {
+		res := post("application/json", `{"name":"`+strings.Repeat("a", 1<<20)+`"}`)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, decode[httpserver.Problem](t, res).Status)
+	})
+
+	t.Run("says which methods it allows", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodDelete, "/greet", nil)
+		r.Header.Set("Accept", "application/json")
+		res := serve(r)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.Code)
+		assert.Equal(t, "GET, HEAD, POST", res.Header().Get("Allow"))
+		assert.Equal(t, http.StatusMethodNotAllowed, decode[httpserver.Problem](t, res).Status)
+	})
+}
+
+func decode[T any](t *testing.T, res *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&v), res.Body.String())
+	return v
+}
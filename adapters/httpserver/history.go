@@ -0,0 +1,85 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+const historyPath = "/history"
+
+// History is the body of GET /history sent to clients that accept
+// application/json, most recent first.
+type History struct {
+	Entries []HistoryEntry `json:"entries"`
+}
+
+type HistoryEntry struct {
+	Interaction string    `json:"interaction"`
+	Name        string    `json:"name"`
+	Adapter     string    `json:"adapter"`
+	At          time.Time `json:"at"`
+}
+
+// listHistory serves the entries in the history matching the name,
+// interaction and limit query parameters.
+func listHistory(service *greeter.Service) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeProblem(w, r, newProblem(http.StatusMethodNotAllowed, ""))
+			return
+		}
+
+		query, problem := readHistoryQuery(r.URL.Query())
+		if problem != nil {
+			writeProblem(w, r, problem)
+			return
+		}
+
+		format := negotiate(r.Header.Get("Accept"), mediaTypeText, mediaTypeJSON)
+		if format == "" {
+			writeProblem(w, r, newProblem(http.StatusNotAcceptable, "supported types are "+mediaTypeText+" and "+mediaTypeJSON))
+			return
+		}
+
+		entries, err := service.ListHistory(r.Context(), query)
+		if err != nil {
+			writeProblem(w, r, problemFor(err))
+			return
+		}
+
+		w.Header().Add("Vary", "Accept")
+		if format == mediaTypeJSON {
+			body := History{Entries: make([]HistoryEntry, 0, len(entries))}
+			for _, entry := range entries {
+				body.Entries = append(body.Entries, HistoryEntry(entry))
+			}
+			w.Header().Set("Content-Type", mediaTypeJSON)
+			_ = json.NewEncoder(w).Encode(body)
+			return
+		}
+		w.Header().Set("Content-Type", mediaTypeText+"; charset=utf-8")
+		for _, entry := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.At.Format(time.RFC3339), entry.Adapter, entry.Interaction, entry.Name)
+		}
+	}
+}
+
+func readHistoryQuery(values url.Values) (history.Query, *Problem) {
+	query := history.Query{Name: values.Get("name"), Interaction: values.Get("interaction")}
+	if limit := values.Get("limit"); limit != "" {
+		var err error
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil || query.Limit < 1 {
+			return history.Query{}, newProblem(http.StatusBadRequest, "limit must be a positive whole number")
+		}
+	}
+	return query, nil
+}
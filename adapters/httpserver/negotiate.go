@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+const (
+	mediaTypeText    = "text/plain"
+	mediaTypeJSON    = "application/json"
+	mediaTypeProblem = "application/problem+json"
+)
+
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// negotiate returns whichever of offers the Accept header ranks highest,
+// preferring earlier offers on a tie, or "" when none of them are acceptable.
+// A missing Accept header accepts anything, so the first offer wins.
+func negotiate(accept string, offers ...string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		if q := quality(ranges, offer); q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// quality is the q-value of the most specific range in ranges matching
+// mediaType, so "text/plain;q=0.1, */*" still ranks text/plain at 0.1.
+func quality(ranges []mediaRange, mediaType string) float64 {
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		if s := r.specificity(mediaType); s > specificity {
+			q, specificity = r.q, s
+		}
+	}
+	return q
+}
+
+func (r mediaRange) specificity(mediaType string) int {
+	switch {
+	case r.mediaType == mediaType:
+		return 2
+	case strings.HasSuffix(r.mediaType, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(r.mediaType, "*")):
+		return 1
+	case r.mediaType == "*/*":
+		return 0
+	default:
+		return -1
+	}
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(raw, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType: mediaType, q: q})
+	}
+	return ranges
+}
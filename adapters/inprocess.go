@@ -0,0 +1,145 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/grpcserver"
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/adapters/webserver"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
+)
+
+// inProcessServers serve what each cmd/* binary would, configured the same
+// way, on lis, and return the URL of their metrics.
+var inProcessServers = map[string]func(t testing.TB, lis net.Listener, cfg inProcessConfig) string{
+	"httpserver": func(t testing.TB, lis net.Listener, cfg inProcessConfig) string {
+		authenticator, _ := cfg.newAuthenticator(t)
+		serveHTTP(t, lis, cfg.httpConfig(), bootstrap.InstrumentHTTP(testLogger(t), telemetry.NewMetrics(), httpserver.NewHandler(cfg.newService(t), authenticator)))
+		return metricsURL(baseURL(lis.Addr().String(), cfg.tls.Enabled()))
+	},
+	"webserver": func(t testing.TB, lis net.Listener, cfg inProcessConfig) string {
+		authenticator, sessions := cfg.newAuthenticator(t)
+		handler, err := webserver.NewHandler(cfg.newService(t), authenticator, sessions)
+		assert.NoError(t, err)
+		serveHTTP(t, lis, cfg.httpConfig(), bootstrap.InstrumentHTTP(testLogger(t), telemetry.NewMetrics(), handler))
+		return metricsURL(baseURL(lis.Addr().String(), cfg.tls.Enabled()))
+	},
+	"grpcserver": func(t testing.TB, lis net.Listener, cfg inProcessConfig) string {
+		metrics := telemetry.NewMetrics()
+		authenticator, _ := cfg.newAuthenticator(t)
+		tlsOpts, err := cfg.tls.GRPCServerOptions()
+		assert.NoError(t, err)
+		s := grpcserver.NewServer(cfg.newService(t), authenticator, append(bootstrap.InstrumentGRPC(testLogger(t), metrics), tlsOpts...)...)
+		go func() {
+			_ = s.Serve(lis)
+		}()
+		t.Cleanup(s.Stop)
+
+		adminLis := listen(t)
+		serveHTTP(t, adminLis, bootstrap.DefaultHTTPConfig(""), bootstrap.AdminHandler(metrics))
+		return metricsURL(baseURL(adminLis.Addr().String(), false))
+	},
+}
+
+// StartInProcessServer serves binToBuild from inside the test binary on an
+// ephemeral port, for when Docker isn't available.
+func StartInProcessServer(t testing.TB, binToBuild string, opts ...ServerOption) Server {
+	t.Helper()
+
+	serve, ok := inProcessServers[binToBuild]
+	if !ok {
+		t.Fatalf("don't know how to run %q in-process", binToBuild)
+	}
+
+	options := newServerOptions(t, opts)
+	lis := listen(t)
+	return Server{
+		Addr:       lis.Addr().String(),
+		MetricsURL: serve(t, lis, newInProcessConfig(t, options.env)),
+		RootCAs:    options.rootCAs,
+	}
+}
+
+// inProcessConfig is what a binary would be configured with.
+type inProcessConfig struct {
+	greeter bootstrap.GreeterConfig
+	auth    bootstrap.AuthConfig
+	tls     bootstrap.TLSConfig
+}
+
+// newInProcessConfig reads config from env the way a binary would read it
+// from its environment.
+func newInProcessConfig(t testing.TB, env map[string]string) inProcessConfig {
+	cfg := inProcessConfig{greeter: bootstrap.DefaultGreeterConfig()}
+	flags := bootstrap.NewFlags("inprocess", func(key string) string { return env[key] })
+	cfg.greeter.RegisterFlags(flags)
+	cfg.auth.RegisterFlags(flags)
+	cfg.tls.RegisterFlags(flags)
+	assert.NoError(t, flags.Parse(nil))
+	assert.NoError(t, errors.Join(cfg.greeter.Validate(), cfg.auth.Validate(), cfg.tls.Validate()))
+	return cfg
+}
+
+func (cfg inProcessConfig) newService(t testing.TB) *greeter.Service {
+	service, closeHistory, err := cfg.greeter.NewService()
+	assert.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, closeHistory()) })
+	return service
+}
+
+// httpConfig is how the HTTP binaries serve, which is only their TLS config
+// in-process.
+func (cfg inProcessConfig) httpConfig() bootstrap.HTTPConfig {
+	httpCfg := bootstrap.DefaultHTTPConfig("")
+	httpCfg.TLS = cfg.tls
+	return httpCfg
+}
+
+func (cfg inProcessConfig) newAuthenticator(t testing.TB) (auth.Authenticator, *auth.Tokens) {
+	authenticator, sessions, err := cfg.auth.NewAuthenticator()
+	assert.NoError(t, err)
+	return authenticator, sessions
+}
+
+func listen(t testing.TB) net.Listener {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	return lis
+}
+
+func serveHTTP(t testing.TB, lis net.Listener, cfg bootstrap.HTTPConfig, handler http.Handler) {
+	ctx, shutdown := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- bootstrap.ServeHTTP(ctx, lis, cfg, handler)
+	}()
+	t.Cleanup(func() {
+		shutdown()
+		assert.NoError(t, <-served)
+	})
+}
+
+// testLogger sends access logs to t.Log, so they show up next to the test
+// that made the request.
+func testLogger(t testing.TB) *slog.Logger {
+	return slog.New(slog.NewTextHandler(testWriter{t}, nil))
+}
+
+type testWriter struct {
+	t testing.TB
+}
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
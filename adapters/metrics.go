@@ -0,0 +1,71 @@
+package adapters
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
+)
+
+func metricsURL(baseURL string) string {
+	return baseURL + bootstrap.MetricsPath
+}
+
+// baseURL is where to reach an HTTP server on addr.
+func baseURL(addr string, tls bool) string {
+	if tls {
+		return "https://" + addr
+	}
+	return "http://" + addr
+}
+
+// AssertInteractionsMeasured scrapes server's metrics and checks it counted
+// and timed at least one of each of interactions.
+func AssertInteractionsMeasured(t testing.TB, server Server, interactions ...string) {
+	t.Helper()
+
+	res, err := server.HTTPClient(5 * time.Second).Get(server.MetricsURL)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(res.Body)
+	assert.NoError(t, err)
+
+	counted := map[string]float64{}
+	if family, ok := families[telemetry.InteractionsMetric]; ok {
+		for _, m := range family.GetMetric() {
+			counted[interactionLabel(m.GetLabel())] += m.GetCounter().GetValue()
+		}
+	}
+	timed := map[string]uint64{}
+	if family, ok := families[telemetry.InteractionDurationMetric]; ok {
+		for _, m := range family.GetMetric() {
+			timed[interactionLabel(m.GetLabel())] += m.GetHistogram().GetSampleCount()
+		}
+	}
+
+	for _, interaction := range interactions {
+		if counted[interaction] == 0 {
+			t.Errorf("%s{interaction=%q} didn't move", telemetry.InteractionsMetric, interaction)
+		}
+		if timed[interaction] == 0 {
+			t.Errorf("%s{interaction=%q} has no samples", telemetry.InteractionDurationMetric, interaction)
+		}
+	}
+}
+
+func interactionLabel(labels []*dto.LabelPair) string {
+	for _, label := range labels {
+		if label.GetName() == "interaction" {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
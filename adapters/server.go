@@ -0,0 +1,141 @@
+package adapters
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/internal/certs"
+)
+
+// ServerModeEnv selects how StartServer runs the system under test. Unset,
+// it runs in Docker when there's a Docker daemon to run in, and in-process
+// when there isn't.
+const ServerModeEnv = "ACCEPTANCE_SERVER"
+
+const (
+	// DockerMode builds and runs the binary's image, failing without Docker.
+	DockerMode = "docker"
+	// InProcessMode serves the binary's handlers from inside the test.
+	InProcessMode = "inprocess"
+)
+
+// TLSModeEnv, when true, has StartServer serve HTTPS, and gRPC over TLS, as
+// though WithTLS were passed.
+const TLSModeEnv = "ACCEPTANCE_TLS"
+
+// Server is a running system under test.
+type Server struct {
+	// Addr is the host:port it serves on.
+	Addr string
+	// MetricsURL is where it exposes Prometheus metrics.
+	MetricsURL string
+	// RootCAs are what its certificate chains to when it serves TLS, and nil
+	// when it doesn't.
+	RootCAs *x509.CertPool
+}
+
+// URL is where to reach an HTTP server, over HTTPS if it serves TLS.
+func (s Server) URL() string {
+	return baseURL(s.Addr, s.RootCAs != nil)
+}
+
+// HTTPClient returns a client that trusts the server's certificate.
+func (s Server) HTTPClient(timeout time.Duration) *http.Client {
+	if s.RootCAs == nil {
+		return &http.Client{Timeout: timeout}
+	}
+	return httpserver.ClientTrusting(s.RootCAs, timeout)
+}
+
+// ClientTLS returns a TLS config that trusts the server's certificate, or nil
+// when it doesn't serve TLS.
+func (s Server) ClientTLS() *tls.Config {
+	if s.RootCAs == nil {
+		return nil
+	}
+	return &tls.Config{RootCAs: s.RootCAs}
+}
+
+// ServerOption configures a server started for a test.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	env     map[string]string
+	tls     bool
+	rootCAs *x509.CertPool
+}
+
+// WithEnv configures the server with environment variables, on top of those
+// every server gets so TestUser can authenticate.
+func WithEnv(env map[string]string) ServerOption {
+	return func(o *serverOptions) {
+		for key, value := range env {
+			o.env[key] = value
+		}
+	}
+}
+
+// WithTLS serves TLS with a certificate from a CA made up for the test, unless
+// the environment already has a TLS_CERT.
+func WithTLS() ServerOption {
+	return func(o *serverOptions) {
+		o.tls = true
+	}
+}
+
+func newServerOptions(t testing.TB, opts []ServerOption) serverOptions {
+	o := serverOptions{env: map[string]string{}}
+	WithEnv(testEnv)(&o)
+	if on, _ := strconv.ParseBool(os.Getenv(TLSModeEnv)); on {
+		WithTLS()(&o)
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.tls && o.env["TLS_CERT"] == "" {
+		o.issueCertificate(t)
+	}
+	return o
+}
+
+// issueCertificate configures the server with a certificate for the
+// addresses tests reach it on.
+func (o *serverOptions) issueCertificate(t testing.TB) {
+	ca, err := certs.NewCA("acceptance CA")
+	assert.NoError(t, err)
+	pair, err := ca.Issue("acceptance server", "localhost", "127.0.0.1", "::1")
+	assert.NoError(t, err)
+	o.env["TLS_CERT"] = string(pair.CertPEM)
+	o.env["TLS_KEY"] = string(pair.KeyPEM)
+	o.rootCAs = ca.Pool()
+}
+
+// StartServer starts binToBuild with StartDockerServer, or with
+// StartInProcessServer when ACCEPTANCE_SERVER=inprocess or when it isn't set
+// and Docker isn't available.
+func StartServer(t testing.TB, port string, binToBuild string, opts ...ServerOption) Server {
+	t.Helper()
+
+	switch mode := os.Getenv(ServerModeEnv); mode {
+	case "":
+		if err := dockerAvailable(); err != nil {
+			t.Logf("running %s in-process, because Docker isn't available: %v", binToBuild, err)
+			return StartInProcessServer(t, binToBuild, opts...)
+		}
+		return StartDockerServer(t, port, binToBuild, opts...).Server
+	case DockerMode:
+		return StartDockerServer(t, port, binToBuild, opts...).Server
+	case InProcessMode:
+		return StartInProcessServer(t, binToBuild, opts...)
+	default:
+		t.Fatalf("%s=%q, want %q or %q", ServerModeEnv, mode, DockerMode, InProcessMode)
+		return Server{}
+	}
+}
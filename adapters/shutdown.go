@@ -0,0 +1,75 @@
+package adapters
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+const drainTimeout = 5 * time.Second
+
+// AssertDrainsOnSIGTERM posts body to path on process in two halves, sending
+// SIGTERM between them, and checks the request still gets a response once
+// the process has stopped accepting connections, and that the process then
+// exits cleanly. It returns the response body.
+func AssertDrainsOnSIGTERM(t testing.TB, process *Process, path string, contentType string, body string) string {
+	t.Helper()
+
+	pipe, write := io.Pipe()
+	req, err := http.NewRequest(http.MethodPost, process.URL()+path, pipe)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "text/plain, text/html")
+
+	type response struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		res, err := process.HTTPClient(2 * drainTimeout).Do(req)
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer res.Body.Close()
+		got, err := io.ReadAll(res.Body)
+		responses <- response{status: res.StatusCode, body: string(got), err: err}
+	}()
+
+	// Once the first half has been read the request is in flight.
+	half := len(body) / 2
+	_, err = io.WriteString(write, body[:half])
+	assert.NoError(t, err)
+	assert.NoError(t, process.Signal(syscall.SIGTERM))
+	awaitStopsAccepting(t, process.Addr)
+	_, err = io.WriteString(write, body[half:])
+	assert.NoError(t, err)
+	assert.NoError(t, write.Close())
+
+	got := <-responses
+	assert.NoError(t, got.err)
+	assert.Equal(t, http.StatusOK, got.status, got.body)
+	assert.NoError(t, process.Wait(t, drainTimeout))
+	return got.body
+}
+
+func awaitStopsAccepting(t testing.TB, addr string) {
+	t.Helper()
+	deadline := time.Now().Add(drainTimeout)
+	for time.Now().Before(deadline) {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return
+		}
+		_ = conn.Close()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s still accepts connections", addr)
+}
@@ -0,0 +1,140 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewLogger returns a logger writing format ("text" or "json") to w.
+func NewLogger(w io.Writer, format string) (*slog.Logger, error) {
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, nil)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, nil)), nil
+	default:
+		return nil, fmt.Errorf("log format %q, want \"text\" or \"json\"", format)
+	}
+}
+
+// LogHTTP writes an access log line per request to logger. It takes the
+// request ID from the X-Request-ID header, minting one if there isn't a
+// usable one, and echoes it back on the response.
+func LogHTTP(logger *slog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		id := requestIDOrNew(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, id)
+
+		ctx, req := withRequest(r.Context(), id)
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "request",
+			slog.String("request_id", id),
+			slog.String("trace_id", traceID(ctx)),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("interaction", req.Interaction()),
+		)
+	})
+}
+
+// LogUnary is LogHTTP for unary RPCs, using x-request-id metadata.
+func LogUnary(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, r := withRequest(ctx, requestIDFromMetadata(ctx))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, r.id))
+
+		res, err := handler(ctx, req)
+		logRPC(ctx, logger, r, info.FullMethod, err, start)
+		return res, err
+	}
+}
+
+// LogStream is LogHTTP for streaming RPCs, using x-request-id metadata.
+func LogStream(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, r := withRequest(stream.Context(), requestIDFromMetadata(stream.Context()))
+		_ = stream.SetHeader(metadata.Pairs(RequestIDMetadata, r.id))
+
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		logRPC(ctx, logger, r, info.FullMethod, err, start)
+		return err
+	}
+}
+
+func logRPC(ctx context.Context, logger *slog.Logger, r *request, method string, err error, start time.Time) {
+	logger.LogAttrs(ctx, slog.LevelInfo, "rpc",
+		slog.String("request_id", r.id),
+		slog.String("trace_id", traceID(ctx)),
+		slog.String("rpc", method),
+		slog.String("code", status.Code(err).String()),
+		slog.Duration("latency", time.Since(start)),
+		slog.String("interaction", r.Interaction()),
+	)
+}
+
+// traceID is the ID of the trace ctx is part of, or "" if it isn't traced.
+func traceID(ctx context.Context) string {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+func requestIDFromMetadata(ctx context.Context) string {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDMetadata); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	return requestIDOrNew(id)
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status, s.wroteHeader = status, true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	s.wroteHeader = true
+	return s.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// contextStream is a grpc.ServerStream carrying the interceptor's context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
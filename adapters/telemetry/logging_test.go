@@ -0,0 +1,189 @@
+package telemetry_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestLogHTTP(t *testing.T) {
+	var logs bytes.Buffer
+	logger, err := telemetry.NewLogger(&logs, "json")
+	assert.NoError(t, err)
+
+	handler := telemetry.LogHTTP(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		telemetry.SetInteraction(r.Context(), "greet")
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	t.Run("echoes the caller's request ID and logs the request", func(t *testing.T) {
+		logs.Reset()
+		req := httptest.NewRequest(http.MethodGet, "/greet?name=Mike", nil)
+		req.Header.Set(telemetry.RequestIDHeader, "abc-123")
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		assert.Equal(t, "abc-123", res.Header().Get(telemetry.RequestIDHeader))
+
+		var line map[string]any
+		assert.NoError(t, json.Unmarshal(logs.Bytes(), &line))
+		assert.Equal[any](t, "abc-123", line["request_id"])
+		assert.Equal[any](t, "/greet", line["path"])
+		assert.Equal[any](t, float64(http.StatusTeapot), line["status"])
+		assert.Equal[any](t, "greet", line["interaction"])
+	})
+
+	t.Run("mints an ID when the caller's isn't usable", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/greet", nil)
+		req.Header.Set(telemetry.RequestIDHeader, "not\nsafe")
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, req)
+
+		id := res.Header().Get(telemetry.RequestIDHeader)
+		assert.NotEqual(t, "", id)
+		assert.NotEqual(t, "not\nsafe", id)
+	})
+}
+
+func TestNewLogger(t *testing.T) {
+	_, err := telemetry.NewLogger(&bytes.Buffer{}, "xml")
+	assert.Error(t, err)
+}
+
+func TestLogGRPC(t *testing.T) {
+	var (
+		logs      = &lockedBuffer{}
+		handledAs = make(chan string, 1)
+	)
+	logger, err := telemetry.NewLogger(logs, "json")
+	assert.NoError(t, err)
+
+	// The interceptors behind the logging ones stand in for the greeter, which
+	// says which interaction it performed.
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(telemetry.LogUnary(logger), func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			telemetry.SetInteraction(ctx, "greet")
+			handledAs <- telemetry.RequestID(ctx)
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(telemetry.LogStream(logger), func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			telemetry.SetInteraction(stream.Context(), "greet")
+			handledAs <- telemetry.RequestID(stream.Context())
+			return handler(srv, stream)
+		}),
+	)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis := bufconn.Listen(1 << 20)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+	withRequestID := func(id string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), telemetry.RequestIDMetadata, id)
+	}
+
+	t.Run("propagates the caller's request ID to unary handlers, echoes it and logs the RPC", func(t *testing.T) {
+		logs.Reset()
+		var header metadata.MD
+		_, err := client.Check(withRequestID("abc-123"), &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+		assert.NoError(t, err)
+
+		assert.Equal(t, "abc-123", <-handledAs)
+		assert.Equal(t, []string{"abc-123"}, header.Get(telemetry.RequestIDMetadata))
+		line := logs.line(t)
+		assert.Equal[any](t, "abc-123", line["request_id"])
+		assert.Equal[any](t, "/grpc.health.v1.Health/Check", line["rpc"])
+		assert.Equal[any](t, "OK", line["code"])
+		assert.Equal[any](t, "greet", line["interaction"])
+	})
+
+	t.Run("mints an ID when the caller's isn't usable", func(t *testing.T) {
+		var header metadata.MD
+		_, err := client.Check(withRequestID("not safe"), &healthpb.HealthCheckRequest{}, grpc.Header(&header))
+		assert.NoError(t, err)
+
+		id := <-handledAs
+		assert.NotEqual(t, "", id)
+		assert.NotEqual(t, "not safe", id)
+		assert.Equal(t, []string{id}, header.Get(telemetry.RequestIDMetadata))
+	})
+
+	t.Run("propagates the caller's request ID to streams, echoes it and logs the RPC", func(t *testing.T) {
+		logs.Reset()
+		ctx, cancel := context.WithCancel(withRequestID("def-456"))
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.NoError(t, err)
+		header, err := stream.Header()
+		assert.NoError(t, err)
+		cancel()
+
+		assert.Equal(t, "def-456", <-handledAs)
+		assert.Equal(t, []string{"def-456"}, header.Get(telemetry.RequestIDMetadata))
+		line := logs.line(t)
+		assert.Equal[any](t, "def-456", line["request_id"])
+		assert.Equal[any](t, "/grpc.health.v1.Health/Watch", line["rpc"])
+		assert.Equal[any](t, "Canceled", line["code"])
+		assert.Equal[any](t, "greet", line["interaction"])
+	})
+}
+
+// lockedBuffer is written to by the server's goroutines while the test reads
+// it.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
+
+// line waits for a log line to be written and decodes it.
+func (b *lockedBuffer) line(t *testing.T) map[string]any {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		b.mu.Lock()
+		written := append([]byte(nil), b.buf.Bytes()...)
+		b.mu.Unlock()
+		if len(written) > 0 || time.Now().After(deadline) {
+			var line map[string]any
+			assert.NoError(t, json.Unmarshal(written, &line), string(written))
+			return line
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,100 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// InteractionsMetric counts interactions served, by interaction and
+	// status code.
+	InteractionsMetric = "greeter_interactions_total"
+	// InteractionDurationMetric is a histogram of how long interactions took
+	// to serve, by interaction.
+	InteractionDurationMetric = "greeter_interaction_duration_seconds"
+)
+
+// Metrics counts and times the interactions a server performs. Each Metrics
+// has its own registry, so several servers can run in one process.
+type Metrics struct {
+	registry     *prometheus.Registry
+	interactions *prometheus.CounterVec
+	durations    *prometheus.HistogramVec
+}
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		interactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: InteractionsMetric,
+			Help: "Interactions served, by interaction and status code.",
+		}, []string{"interaction", "code"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    InteractionDurationMetric,
+			Help:    "How long interactions took to serve.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"interaction"}),
+	}
+	m.registry.MustRegister(
+		m.interactions,
+		m.durations,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler serves the metrics in Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) observe(r *request, code string, start time.Time) {
+	interaction := r.Interaction()
+	if interaction == "" {
+		return
+	}
+	m.interactions.WithLabelValues(interaction, code).Inc()
+	m.durations.WithLabelValues(interaction).Observe(time.Since(start).Seconds())
+}
+
+// MeasureHTTP records requests to next that perform an interaction in m.
+func MeasureHTTP(m *Metrics, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx, req := withRequest(r.Context(), requestIDOrNew(r.Header.Get(RequestIDHeader)))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+		m.observe(req, strconv.Itoa(recorder.status), start)
+	})
+}
+
+// MeasureUnary is MeasureHTTP for unary RPCs.
+func MeasureUnary(m *Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx, r := withRequest(ctx, requestIDFromMetadata(ctx))
+		res, err := handler(ctx, req)
+		m.observe(r, status.Code(err).String(), start)
+		return res, err
+	}
+}
+
+// MeasureStream is MeasureHTTP for streaming RPCs.
+func MeasureStream(m *Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, r := withRequest(stream.Context(), requestIDFromMetadata(stream.Context()))
+		err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+		m.observe(r, status.Code(err).String(), start)
+		return err
+	}
+}
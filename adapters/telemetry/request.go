@@ -0,0 +1,88 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+)
+
+const (
+	// RequestIDHeader carries a request's ID on HTTP requests and responses.
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDMetadata carries a request's ID in gRPC metadata.
+	RequestIDMetadata = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+type requestKey struct{}
+
+// request is what the middleware knows about a request in flight, filled in
+// by handlers as they go.
+type request struct {
+	id string
+
+	mu          sync.Mutex
+	interaction string
+}
+
+// withRequest returns ctx carrying a request, reusing the one an outer
+// middleware already added so they all agree on its ID and interaction.
+func withRequest(ctx context.Context, id string) (context.Context, *request) {
+	if r, ok := ctx.Value(requestKey{}).(*request); ok {
+		return ctx, r
+	}
+	r := &request{id: id}
+	return context.WithValue(ctx, requestKey{}, r), r
+}
+
+func (r *request) Interaction() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.interaction
+}
+
+// SetInteraction records which interaction the request in ctx performed, so
+// it can be logged, measured and found in traces.
+func SetInteraction(ctx context.Context, interaction string) {
+	setSpanInteraction(ctx, interaction)
+	if r, ok := ctx.Value(requestKey{}).(*request); ok {
+		r.mu.Lock()
+		r.interaction = interaction
+		r.mu.Unlock()
+	}
+}
+
+// RequestID is the ID of the request in ctx, or "" outside the middleware.
+func RequestID(ctx context.Context) string {
+	if r, ok := ctx.Value(requestKey{}).(*request); ok {
+		return r.id
+	}
+	return ""
+}
+
+// requestIDOrNew returns id if it's safe to log and echo back, otherwise a new
+// random ID.
+func requestIDOrNew(id string) string {
+	if validRequestID(id) {
+		return id
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/stats"
+)
+
+// InteractionAttribute is set on a request's span once it's known which
+// interaction it performs.
+const InteractionAttribute = attribute.Key("greeter.interaction")
+
+// InstallTracing makes this process record spans with processor, and carry
+// W3C trace context on the calls it makes and serves. The returned func
+// flushes and stops it.
+func InstallTracing(service string, processor sdktrace.SpanProcessor) func(context.Context) error {
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(service))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown
+}
+
+// TraceHTTP starts a server span for each request to next, continuing the
+// caller's trace if it sent one.
+func TraceHTTP(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.server", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + r.URL.Path
+	}))
+}
+
+// TraceGRPC is TraceHTTP for RPCs.
+func TraceGRPC() stats.Handler {
+	return otelgrpc.NewServerHandler()
+}
+
+// TraceGRPCClient propagates the caller's trace on RPCs it makes.
+func TraceGRPCClient() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// InjectHTTP adds ctx's trace context to header.
+func InjectHTTP(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+func setSpanInteraction(ctx context.Context, interaction string) {
+	trace.SpanFromContext(ctx).SetAttributes(InteractionAttribute.String(interaction))
+}
@@ -0,0 +1,64 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/specifications"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordTraces sends spans from the whole test binary to an in-memory
+// exporter until t finishes. Tracing is global, so a test using it mustn't
+// run in parallel, and only sees servers' spans when they run in-process.
+func RecordTraces(t testing.TB) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown := telemetry.InstallTracing("acceptance-tests", sdktrace.NewSimpleSpanProcessor(exporter))
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return exporter
+}
+
+// AssertTracedPerStep checks that each specification step in spans was one
+// trace that reached a server, and that servers saw no requests outside them.
+func AssertTracedPerStep(t testing.TB, spans tracetest.SpanStubs) {
+	t.Helper()
+
+	steps := map[trace.TraceID]string{}
+	served := map[trace.TraceID]int{}
+	for _, span := range spans {
+		switch {
+		case span.InstrumentationScope.Name == specifications.TracerName:
+			if other, ok := steps[span.SpanContext.TraceID()]; ok {
+				t.Errorf("steps %q and %q share a trace", other, span.Name)
+			}
+			steps[span.SpanContext.TraceID()] = span.Name
+		case span.SpanKind == trace.SpanKindServer:
+			served[span.SpanContext.TraceID()]++
+		}
+	}
+
+	if len(steps) == 0 {
+		t.Fatal("no specification steps were traced")
+	}
+	for traceID, step := range steps {
+		if served[traceID] == 0 {
+			t.Errorf("step %q's trace %s never reached a server", step, traceID)
+		}
+	}
+	for traceID, count := range served {
+		if _, ok := steps[traceID]; !ok {
+			t.Errorf("trace %s has %d server spans but no specification step", traceID, count)
+		}
+	}
+}
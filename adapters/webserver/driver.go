@@ -1,44 +1,235 @@
 package webserver
 
 import (
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
 	"github.com/quii/go-specs-greet/adapters/webserver/internal/pages"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
+// serverKeyTimeout is how long NewDriver waits for the server's certificate.
+const serverKeyTimeout = 5 * time.Second
+
 type Driver struct {
 	baseURL string
 	browser *rod.Browser
+	apiKey  string
+	rootCAs *x509.CertPool
+	session *session
 }
 
-func NewDriver(baseURL string) (*Driver, func() error) {
-	browser := rod.New().MustConnect()
-	return &Driver{baseURL: baseURL, browser: browser}, browser.Close
+// session is whether the driver's browser has logged in yet.
+type session struct {
+	mu       sync.Mutex
+	loggedIn bool
 }
 
-func (d Driver) Curse(name string) (string, error) {
-	var (
-		page      = d.browser.MustPage(d.baseURL)
-		replyPage = pages.Reply{Page: page}
-		formPage  = pages.Form{Page: page}
-	)
+type DriverOption func(*Driver)
 
-	if err := formPage.Curse(name); err != nil {
-		return "", err
+// WithAPIKey logs the browser in with key before it does anything else.
+func WithAPIKey(key string) DriverOption {
+	return func(d *Driver) {
+		d.apiKey = key
 	}
+}
 
-	return replyPage.ReadReply()
+// WithRootCAs trusts the server if its certificate chains to pool, such as
+// one signed by a development CA, instead of the system's roots. The browser
+// is launched trusting the key of the certificate the server presents then,
+// so NewDriver fails if the server isn't serving yet.
+func WithRootCAs(pool *x509.CertPool) DriverOption {
+	return func(d *Driver) {
+		d.rootCAs = pool
+	}
 }
 
-func (d Driver) Greet(name string) (string, error) {
+func NewDriver(baseURL string, opts ...DriverOption) (*Driver, func() error) {
+	d := &Driver{baseURL: baseURL, session: &session{}}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	l := launcher.New()
+	if d.rootCAs != nil {
+		// The browser can't be given roots of its own, so the driver checks
+		// the server's certificate chains to them and has the browser trust
+		// that certificate's key, and no other.
+		key, err := d.serverKey()
+		if err != nil {
+			panic(err)
+		}
+		if err := l.IgnoreCerts([]crypto.PublicKey{key}); err != nil {
+			panic(err)
+		}
+	}
+	d.browser = rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	return d, func() error {
+		err := d.browser.Close()
+		l.Cleanup()
+		return err
+	}
+}
+
+func (d Driver) Curse(ctx context.Context, name string) (string, error) {
+	return d.CurseIn(ctx, "", name)
+}
+
+func (d Driver) CurseIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Cursing.Name, locale, name)
+}
+
+func (d Driver) Greet(ctx context.Context, name string) (string, error) {
+	return d.GreetIn(ctx, "", name)
+}
+
+func (d Driver) GreetIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Greeting.Name, locale, name)
+}
+
+func (d Driver) Interact(ctx context.Context, interaction string, locale string, name string) (string, error) {
+	page, err := d.openPage(ctx, locale)
+	if err != nil {
+		return "", err
+	}
+	defer page.Close()
+
 	var (
-		page      = d.browser.MustPage(d.baseURL)
 		replyPage = pages.Reply{Page: page}
 		formPage  = pages.Form{Page: page}
 	)
 
-	if err := formPage.Greet(name); err != nil {
+	if err := formPage.Interact(interaction, name); err != nil {
 		return "", err
 	}
 
 	return replyPage.ReadReply()
 }
+
+func (d Driver) History(ctx context.Context, query history.Query) ([]history.Entry, error) {
+	values := url.Values{}
+	if query.Name != "" {
+		values.Set("name", query.Name)
+	}
+	if query.Interaction != "" {
+		values.Set("interaction", query.Interaction)
+	}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+	page, err := d.openPath(ctx, "", "/history?"+values.Encode())
+	if err != nil {
+		return nil, err
+	}
+	defer page.Close()
+
+	return pages.History{Page: page}.ReadEntries()
+}
+
+// openPage opens the form bound to ctx, so every wait on the page is
+// abandoned once ctx is cancelled or its deadline passes. A non-empty locale
+// is sent as the browser's Accept-Language, which preselects the form's
+// language picker just as it would for a real visitor.
+func (d Driver) openPage(ctx context.Context, locale string) (*rod.Page, error) {
+	return d.openPath(ctx, locale, "/")
+}
+
+// openPath is openPage for any page on the site, logging in first if the
+// driver has an API key.
+func (d Driver) openPath(ctx context.Context, locale string, path string) (*rod.Page, error) {
+	if err := d.logIn(ctx); err != nil {
+		return nil, err
+	}
+	return d.navigate(ctx, locale, path)
+}
+
+// logIn logs the browser in, keeping the session cookie for later pages. It
+// tries again next time if it fails.
+func (d Driver) logIn(ctx context.Context) error {
+	if d.apiKey == "" {
+		return nil
+	}
+	d.session.mu.Lock()
+	defer d.session.mu.Unlock()
+	if d.session.loggedIn {
+		return nil
+	}
+
+	page, err := d.navigate(ctx, "", "/login")
+	if err != nil {
+		return err
+	}
+	defer page.Close()
+	if err := (pages.Login{Page: page}).LogIn(d.apiKey); err != nil {
+		return err
+	}
+	d.session.loggedIn = true
+	return nil
+}
+
+// serverKey returns the public key of the server's certificate, once it has
+// checked the certificate chains to the driver's root CAs.
+func (d Driver) serverKey() (crypto.PublicKey, error) {
+	u, err := url.Parse(d.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("%s isn't https, so there's no certificate to trust", d.baseURL)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "443"
+	}
+	dialer := tls.Dialer{Config: &tls.Config{RootCAs: d.rootCAs, ServerName: u.Hostname()}}
+	ctx, cancel := context.WithTimeout(context.Background(), serverKeyTimeout)
+	defer cancel()
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates[0].PublicKey, nil
+}
+
+func (d Driver) navigate(ctx context.Context, locale string, path string) (*rod.Page, error) {
+	page, err := d.browser.Context(ctx).Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, err
+	}
+	// Extra headers apply to every request the page makes, so the form
+	// submission carries the trace context too.
+	header := http.Header{}
+	telemetry.InjectHTTP(ctx, header)
+	if locale != "" {
+		header.Set("Accept-Language", locale)
+	}
+	if len(header) > 0 {
+		var dict []string
+		for key := range header {
+			dict = append(dict, key, header.Get(key))
+		}
+		if _, err := page.SetExtraHeaders(dict); err != nil {
+			return nil, err
+		}
+	}
+	if err := page.Navigate(strings.TrimSuffix(d.baseURL, "/") + path); err != nil {
+		return nil, err
+	}
+	return page, page.WaitLoad()
+}
@@ -3,15 +3,22 @@ package webserver
 import (
 	"embed"
 	_ "embed"
+	"errors"
+	"fmt"
 	"html/template"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
 	"github.com/quii/go-specs-greet/domain/interactions"
-)
-
-const (
-	greetPath = "/greet"
-	cursePath = "/curse"
+	"golang.org/x/text/language"
 )
 
 var (
@@ -19,36 +26,273 @@ var (
 	templates embed.FS
 )
 
-func NewHandler() (http.Handler, error) {
+// AdapterName is what this adapter is called in the history.
+const AdapterName = "webserver"
+
+// NewHandler serves a form for every interaction in the default registry,
+// each posting to /{name}, and their history at /history. Visitors log in at
+// /login with an API key authenticator accepts, and are given a session
+// token from sessions.
+func NewHandler(service *greeter.Service, authenticator auth.Authenticator, sessions *auth.Tokens) (http.Handler, error) {
 	templ, err := template.ParseFS(templates, "markup/*.gohtml")
 	if err != nil {
 		return nil, err
 	}
 
-	handler := handler{templ: templ}
+	handler := handler{
+		templ:         templ,
+		service:       service,
+		authenticator: authenticator,
+		sessions:      sessions,
+	}
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", handler.form)
-	mux.HandleFunc(greetPath, handler.replyWith(interactions.Greet))
-	mux.HandleFunc(cursePath, handler.replyWith(interactions.Curse))
-	return mux, nil
+	mux.HandleFunc("/{$}", handler.form)
+	for _, interaction := range interactions.DefaultRegistry.All() {
+		mux.HandleFunc("/"+interaction.Name, handler.replyWith(interaction))
+	}
+	mux.HandleFunc("/history", handler.history)
+	mux.HandleFunc("/login", handler.login)
+	mux.HandleFunc("/logout", handler.logout)
+	mux.HandleFunc("/", handler.notFound)
+	return strictTransportSecurity(handler.authenticate(mux)), nil
+}
+
+// hstsMaxAge is two years, as recommended for preload lists.
+const hstsMaxAge = 2 * 365 * 24 * time.Hour
+
+// strictTransportSecurity tells browsers that reached the site over HTTPS to
+// never use plain HTTP for it again. It's not sent over HTTP, where a
+// man in the middle could strip or forge it anyway.
+func strictTransportSecurity(next http.Handler) http.Handler {
+	value := fmt.Sprintf("max-age=%d; includeSubDomains", int(hstsMaxAge.Seconds()))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil {
+			w.Header().Set("Strict-Transport-Security", value)
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 type handler struct {
-	templ *template.Template
+	templ         *template.Template
+	service       *greeter.Service
+	authenticator auth.Authenticator
+	sessions      *auth.Tokens
+}
+
+type page struct {
+	Lang         language.Tag
+	User         string
+	Locales      []localeOption
+	Interactions []interactionForm
+	Reply        string
+	Error        *formError
+	HistoryQuery history.Query
+	History      []history.Entry
+}
+
+// Failed reports whether the form for interaction is being shown again
+// because of an error.
+func (p page) Failed(interaction string) bool {
+	return p.Error != nil && p.Error.Interaction == interaction
+}
+
+type localeOption struct {
+	Tag      language.Tag
+	Name     string
+	Selected bool
 }
 
-func (h handler) replyWith(interact func(name string) string) func(http.ResponseWriter, *http.Request) {
+type interactionForm struct {
+	Name  string
+	Label string
+}
+
+// formError is shown inline next to the field the visitor needs to fix, or at
+// the top of the page when it isn't about any one form.
+type formError struct {
+	Interaction string
+	Name        string
+	Code        string
+	Detail      string
+	// RetryAfter is how many seconds a throttled visitor should wait.
+	RetryAfter int
+}
+
+func (h handler) replyWith(interaction interactions.Interaction) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Err() != nil {
+			return
+		}
+		// Interactions only answer the form's POST, so other sites can't
+		// make visitors interact just by linking to them.
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		telemetry.SetInteraction(r.Context(), interaction.Name)
 		if err := r.ParseForm(); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		if err := h.templ.ExecuteTemplate(w, "reply.gohtml", interact(r.Form.Get("name"))); err != nil {
+
+		locale := localeFrom(r)
+		name := r.PostForm.Get("name")
+		message, err := h.service.Interact(r.Context(), greeter.Call{
+			Interaction: interaction,
+			Locale:      locale,
+			Name:        name,
+			Caller:      callerOf(r),
+			User:        authn.UserFrom(r.Context()),
+			Adapter:     AdapterName,
+		})
+
+		var (
+			domainErr *interactions.Error
+			throttled *interactions.ThrottledError
+		)
+		switch {
+		case errors.As(err, &throttled):
+			h.renderThrottled(w, locale, throttled)
+			return
+		case errors.As(err, &domainErr) && isForVisitorToFix(domainErr.Kind):
+			w.WriteHeader(statusFor(domainErr))
+			h.renderForm(w, locale, &formError{
+				Interaction: interaction.Name,
+				Name:        name,
+				Code:        domainErr.Code,
+				Detail:      domainErr.Detail,
+			})
+			return
+		case err != nil:
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		reply := page{Lang: locale, Reply: message}
+		if err := h.templ.ExecuteTemplate(w, "reply.gohtml", reply); err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
 	}
 }
 
-func (h handler) form(w http.ResponseWriter, _ *http.Request) {
-	_ = h.templ.ExecuteTemplate(w, "form.gohtml", nil)
+// history lists past interactions matching the name, interaction and limit
+// query parameters. An unusable limit is ignored rather than refused.
+func (h handler) history(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	limit, _ := strconv.Atoi(r.FormValue("limit"))
+	historyPage := page{
+		Lang:         localeFrom(r),
+		HistoryQuery: history.Query{Name: r.FormValue("name"), Interaction: r.FormValue("interaction"), Limit: limit},
+	}
+	entries, err := h.service.ListHistory(r.Context(), historyPage.HistoryQuery)
+
+	var domainErr *interactions.Error
+	switch {
+	case errors.As(err, &domainErr):
+		w.WriteHeader(http.StatusBadRequest)
+		historyPage.Error = &formError{Name: historyPage.HistoryQuery.Name, Code: domainErr.Code, Detail: domainErr.Detail}
+	case err != nil:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	default:
+		historyPage.History = entries
+	}
+
+	if err := h.templ.ExecuteTemplate(w, "history.gohtml", historyPage); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// isForVisitorToFix reports whether errors of kind are something the visitor
+// can do something about, so are shown on the form.
+func isForVisitorToFix(kind interactions.Kind) bool {
+	switch kind {
+	case interactions.KindInvalid, interactions.KindRefused, interactions.KindUnauthenticated:
+		return true
+	default:
+		return false
+	}
+}
+
+// statusFor is the status a form shown again because of domainErr is sent
+// with.
+func statusFor(domainErr *interactions.Error) int {
+	switch domainErr.Kind {
+	case interactions.KindRefused:
+		return http.StatusUnprocessableEntity
+	case interactions.KindUnauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// renderThrottled asks the visitor to slow down, telling them (and their
+// browser) when they can try again.
+func (h handler) renderThrottled(w http.ResponseWriter, locale language.Tag, throttled *interactions.ThrottledError) {
+	retryAfter := int(math.Ceil(throttled.RetryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	w.WriteHeader(http.StatusTooManyRequests)
+	_ = h.templ.ExecuteTemplate(w, "throttled.gohtml", page{
+		Lang: locale,
+		Error: &formError{
+			Code:       interactions.ErrTooManyRequests.Code,
+			Detail:     interactions.ErrTooManyRequests.Detail,
+			RetryAfter: retryAfter,
+		},
+	})
+}
+
+// callerOf identifies who sent r by their IP address.
+func callerOf(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func (h handler) form(w http.ResponseWriter, r *http.Request) {
+	h.renderForm(w, localeFrom(r), nil)
+}
+
+func (h handler) notFound(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+	h.renderForm(w, localeFrom(r), &formError{
+		Code:   interactions.ErrUnknownInteraction.Code,
+		Detail: interactions.ErrUnknownInteraction.Detail,
+	})
+}
+
+func (h handler) renderForm(w http.ResponseWriter, locale language.Tag, formErr *formError) {
+	form := page{Lang: locale, Error: formErr}
+	for _, tag := range interactions.Locales() {
+		form.Locales = append(form.Locales, localeOption{
+			Tag:      tag,
+			Name:     interactions.LanguageName(tag),
+			Selected: tag == locale,
+		})
+	}
+	for _, interaction := range interactions.DefaultRegistry.All() {
+		form.Interactions = append(form.Interactions, interactionForm{
+			Name:  interaction.Name,
+			Label: interaction.Label(locale),
+		})
+	}
+	_ = h.templ.ExecuteTemplate(w, "form.gohtml", form)
+}
+
+// localeFrom prefers the language picked on the form, falling back to the
+// browser's Accept-Language.
+func localeFrom(r *http.Request) language.Tag {
+	if picked := r.FormValue("locale"); picked != "" {
+		return interactions.ParseLocale(picked)
+	}
+	return interactions.ParseLocale(r.Header.Get("Accept-Language"))
 }
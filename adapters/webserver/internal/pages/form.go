@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/input"
+	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
 type Form struct {
@@ -12,17 +13,27 @@ type Form struct {
 }
 
 func (f Form) Greet(name string) error {
-	greetInput, err := f.Page.Element("#greet-input")
-	if err != nil {
-		return fmt.Errorf("couldn't find #greet-input on Page")
-	}
-	return greetInput.MustInput(name).Type(input.Enter)
+	return f.Interact(interactions.Greeting.Name, name)
 }
 
 func (f Form) Curse(name string) error {
-	curseInput, err := f.Page.Element("#curse-input")
+	return f.Interact(interactions.Cursing.Name, name)
+}
+
+// Interact fills in and submits the form for interaction. The page must have
+// finished loading, as a form that isn't there means the site doesn't offer
+// that interaction.
+func (f Form) Interact(interaction string, name string) error {
+	selector := "#" + interaction + "-input"
+	has, nameInput, err := f.Page.Has(selector)
 	if err != nil {
-		return fmt.Errorf("couldn't find #curse-input on Page")
+		return fmt.Errorf("couldn't look for %s on Page: %w", selector, err)
+	}
+	if !has {
+		return interactions.ErrUnknownInteraction
+	}
+	if err := nameInput.Input(name); err != nil {
+		return err
 	}
-	return curseInput.MustInput(name).Type(input.Enter)
+	return nameInput.Type(input.Enter)
 }
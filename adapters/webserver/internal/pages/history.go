@@ -0,0 +1,66 @@
+package pages
+
+import (
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+type History struct {
+	Page *rod.Page
+}
+
+// ReadEntries waits for the history table, or for the page to come back with
+// an error, which is returned as the domain error it describes.
+func (h History) ReadEntries() ([]history.Entry, error) {
+	table, err := h.Page.Race().
+		Element("#history").
+		Element("#error").Handle(readError).
+		Do()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := table.Elements("tr.entry")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]history.Entry, 0, len(rows))
+	for _, row := range rows {
+		entry, err := readEntry(row)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func readEntry(row *rod.Element) (history.Entry, error) {
+	var entry history.Entry
+	for field, into := range map[string]*string{
+		"interaction": &entry.Interaction,
+		"name":        &entry.Name,
+		"adapter":     &entry.Adapter,
+	} {
+		cell, err := row.Element(`[data-field="` + field + `"]`)
+		if err != nil {
+			return entry, err
+		}
+		if *into, err = cell.Text(); err != nil {
+			return entry, err
+		}
+	}
+
+	when, err := row.Element("time")
+	if err != nil {
+		return entry, err
+	}
+	datetime, err := when.Attribute("datetime")
+	if err != nil || datetime == nil {
+		return entry, err
+	}
+	entry.At, err = time.Parse(time.RFC3339Nano, *datetime)
+	return entry, err
+}
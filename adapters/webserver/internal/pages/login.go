@@ -0,0 +1,31 @@
+package pages
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+)
+
+type Login struct {
+	Page *rod.Page
+}
+
+// LogIn submits key and waits to be sent back to the form, or for the login
+// page to come back with an error, which is returned as the domain error it
+// describes.
+func (l Login) LogIn(key string) error {
+	keyInput, err := l.Page.Element("#api-key-input")
+	if err != nil {
+		return err
+	}
+	if err := keyInput.Input(key); err != nil {
+		return err
+	}
+	if err := keyInput.Type(input.Enter); err != nil {
+		return err
+	}
+	_, err = l.Page.Race().
+		Element("form input[name='name']").
+		Element("#error").Handle(readError).
+		Do()
+	return err
+}
@@ -1,19 +1,65 @@
 package pages
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
 type Reply struct {
 	Page *rod.Page
 }
 
+// ReadReply waits for either the reply or the form to come back with an
+// inline error, which is returned as the domain error it describes.
 func (r Reply) ReadReply() (string, error) {
-	greeting, err := r.Page.Element("#reply")
+	greeting, err := r.Page.Race().
+		Element("#reply").
+		Element("#error").Handle(readError).
+		Do()
 	if err != nil {
+		return "", err
+	}
+	if greeting == nil {
 		return "", fmt.Errorf("couldn't find #reply on Page")
 	}
 	return greeting.Text()
 }
+
+func readError(el *rod.Element) error {
+	code, err := el.Attribute("data-code")
+	if err != nil {
+		return err
+	}
+	if code != nil {
+		if domainErr, ok := interactions.LookupError(*code); ok {
+			return withRetryAfter(el, domainErr)
+		}
+	}
+	text, err := el.Text()
+	if err != nil {
+		return err
+	}
+	return errors.New(text)
+}
+
+// withRetryAfter turns ErrTooManyRequests into the ThrottledError el
+// describes, when it says how long to wait.
+func withRetryAfter(el *rod.Element, domainErr *interactions.Error) error {
+	if domainErr != interactions.ErrTooManyRequests {
+		return domainErr
+	}
+	retryAfter, err := el.Attribute("data-retry-after")
+	if err != nil || retryAfter == nil {
+		return domainErr
+	}
+	seconds, err := strconv.Atoi(*retryAfter)
+	if err != nil {
+		return domainErr
+	}
+	return &interactions.ThrottledError{RetryAfter: time.Duration(seconds) * time.Second}
+}
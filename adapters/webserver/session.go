@@ -0,0 +1,102 @@
+package webserver
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/quii/go-specs-greet/adapters/authn"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+const (
+	sessionCookie = "session"
+	sessionTTL    = 12 * time.Hour
+)
+
+// authenticate lets requests through with the user their session cookie was
+// issued to, if they have one. Expired or forged cookies are cleared, leaving
+// the visitor logged out. The cookie is SameSite=Lax, so other sites can't
+// post forms on a logged in visitor's behalf, and everything a visitor does
+// is a POST.
+func (h handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie(sessionCookie)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user, err := h.sessions.Verify(cookie.Value)
+		if err != nil {
+			clearSession(w, r)
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(authn.WithUser(r.Context(), user)))
+	})
+}
+
+// login shows the login form, and logs in visitors who post an API key the
+// authenticator accepts before sending them back to the home page.
+func (h handler) login(w http.ResponseWriter, r *http.Request) {
+	loginPage := page{Lang: localeFrom(r), User: authn.UserFrom(r.Context())}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		user, err := h.authenticator.Authenticate(auth.Credentials{APIKey: r.PostFormValue("api_key")})
+		if err == nil && user == "" {
+			err = interactions.ErrInvalidCredentials
+		}
+		var domainErr *interactions.Error
+		switch {
+		case errors.As(err, &domainErr):
+			w.WriteHeader(http.StatusUnauthorized)
+			loginPage.Error = &formError{Code: domainErr.Code, Detail: domainErr.Detail}
+		case err != nil:
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		default:
+			http.SetCookie(w, &http.Cookie{
+				Name:     sessionCookie,
+				Value:    h.sessions.Issue(user, sessionTTL),
+				Path:     "/",
+				MaxAge:   int(sessionTTL.Seconds()),
+				Secure:   r.TLS != nil,
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := h.templ.ExecuteTemplate(w, "login.gohtml", loginPage); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h handler) logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	clearSession(w, r)
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func clearSession(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
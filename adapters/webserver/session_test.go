@@ -0,0 +1,143 @@
+package webserver_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters/webserver"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+func TestSessions(t *testing.T) {
+	sessions := auth.NewTokens([]byte("a-session-secret-of-at-least-32-bytes"))
+	sessions.Audience = "sessions"
+	handler, err := webserver.NewHandler(greeter.NewService(history.NewInMemory(), nil), auth.APIKeys{"s3cret": "alice"}, sessions)
+	assert.NoError(t, err)
+
+	serve := func(r *http.Request) *http.Response {
+		res := httptest.NewRecorder()
+		handler.ServeHTTP(res, r)
+		return res.Result()
+	}
+	logIn := func(key string, overTLS bool) *http.Response {
+		r := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(url.Values{"api_key": {key}}.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if overTLS {
+			r.TLS = &tls.ConnectionState{}
+		}
+		return serve(r)
+	}
+	withSession := func(r *http.Request, value string) *http.Request {
+		r.AddCookie(&http.Cookie{Name: "session", Value: value})
+		return r
+	}
+
+	t.Run("logs in visitors with a key and sends them home", func(t *testing.T) {
+		res := logIn("s3cret", false)
+		assert.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.Equal(t, "/", res.Header.Get("Location"))
+
+		cookie := sessionCookie(t, res)
+		assert.Equal(t, "/", cookie.Path)
+		assert.Equal(t, int((12 * time.Hour).Seconds()), cookie.MaxAge)
+		assert.True(t, cookie.HttpOnly, "the session is readable by scripts")
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		assert.False(t, cookie.Secure, "the session can't be sent back over HTTP")
+
+		user, err := sessions.Verify(cookie.Value)
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", user)
+	})
+
+	t.Run("only sends the session back over HTTPS when it was issued over HTTPS", func(t *testing.T) {
+		assert.True(t, sessionCookie(t, logIn("s3cret", true)).Secure, "the session can be sent over HTTP")
+	})
+
+	t.Run("refuses keys it doesn't accept", func(t *testing.T) {
+		res := logIn("guess", false)
+		assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, 0, len(res.Cookies()))
+	})
+
+	t.Run("recognises visitors by their session", func(t *testing.T) {
+		r := withSession(httptest.NewRequest(http.MethodGet, "/login", nil), sessions.Issue("alice", time.Hour))
+		res := serve(r)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, body(t, res), "logged in as alice")
+	})
+
+	t.Run("clears forged sessions, leaving the visitor logged out", func(t *testing.T) {
+		forged := auth.NewTokens([]byte("someone-else's-secret-of-32-bytes!!")).Issue("alice", time.Hour)
+		res := serve(withSession(httptest.NewRequest(http.MethodGet, "/login", nil), forged))
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.True(t, sessionCookie(t, res).MaxAge < 0, "the forged session wasn't cleared")
+		assert.NotContains(t, body(t, res), "logged in as")
+	})
+
+	t.Run("interacts with a POST as the visitor", func(t *testing.T) {
+		r := withSession(httptest.NewRequest(http.MethodPost, "/curse", strings.NewReader(url.Values{"name": {"Chris"}}.Encode())), sessions.Issue("alice", time.Hour))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		res := serve(r)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Contains(t, body(t, res), "Go to hell, Chris!")
+	})
+
+	t.Run("doesn't interact with a GET, which other sites can make visitors send", func(t *testing.T) {
+		r := withSession(httptest.NewRequest(http.MethodGet, "/curse?name=Victim", nil), sessions.Issue("alice", time.Hour))
+		res := serve(r)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, "POST", res.Header.Get("Allow"))
+		assert.NotContains(t, body(t, res), "Victim")
+	})
+
+	t.Run("reads the name from the form, not the query", func(t *testing.T) {
+		r := withSession(httptest.NewRequest(http.MethodPost, "/curse?name=Victim", strings.NewReader(url.Values{"name": {"Chris"}}.Encode())), sessions.Issue("alice", time.Hour))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		res := serve(r)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NotContains(t, body(t, res), "Victim")
+	})
+
+	t.Run("logs out with a POST", func(t *testing.T) {
+		r := withSession(httptest.NewRequest(http.MethodPost, "/logout", nil), sessions.Issue("alice", time.Hour))
+		res := serve(r)
+		assert.Equal(t, http.StatusSeeOther, res.StatusCode)
+		assert.True(t, sessionCookie(t, res).MaxAge < 0, "the session wasn't cleared")
+	})
+
+	t.Run("doesn't log out with a GET, which other sites can make visitors send", func(t *testing.T) {
+		r := withSession(httptest.NewRequest(http.MethodGet, "/logout", nil), sessions.Issue("alice", time.Hour))
+		res := serve(r)
+		assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+		assert.Equal(t, "POST", res.Header.Get("Allow"))
+		assert.Equal(t, 0, len(res.Cookies()))
+	})
+}
+
+func sessionCookie(t *testing.T, res *http.Response) *http.Cookie {
+	t.Helper()
+	for _, cookie := range res.Cookies() {
+		if cookie.Name == "session" {
+			return cookie
+		}
+	}
+	t.Fatalf("no session cookie in %v", res.Header["Set-Cookie"])
+	return nil
+}
+
+func body(t *testing.T, res *http.Response) string {
+	t.Helper()
+	var b strings.Builder
+	_, err := io.Copy(&b, res.Body)
+	assert.NoError(t, err)
+	return b.String()
+}
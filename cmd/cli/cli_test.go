@@ -0,0 +1,43 @@
+package main_test
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+func TestBatch(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	binary := adapters.BuildBinary(t, "cli")
+
+	t.Run("greets each line in plain text, failing if any line is invalid", func(t *testing.T) {
+		cmd := exec.Command(binary, "greet", "--batch", "--locale", "de")
+		cmd.Stdin = strings.NewReader("Mike\nMi\u0085ke\nChris\n")
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		stdout, err := cmd.Output()
+
+		var exitErr *exec.ExitError
+		assert.True(t, errors.As(err, &exitErr), "got %v", err)
+		assert.Equal(t, 1, exitErr.ExitCode())
+		assert.Equal(t, "Hallo, Mike\nHallo, Chris\n", string(stdout))
+		assert.Contains(t, stderr.String(), interactions.ErrNameHasControlCharacters.Error())
+	})
+
+	t.Run("exits cleanly when every line is valid", func(t *testing.T) {
+		cmd := exec.Command(binary, "greet", "--batch")
+		cmd.Stdin = strings.NewReader("Mike\nChris\n")
+		stdout, err := cmd.Output()
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, Mike\nHello, Chris\n", string(stdout))
+	})
+}
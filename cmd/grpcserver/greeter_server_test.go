@@ -1,25 +1,35 @@
 package main_test
 
 import (
-	"fmt"
+	"context"
 	"testing"
 
+	"github.com/alecthomas/assert/v2"
 	"github.com/quii/go-specs-greet/adapters"
 	"github.com/quii/go-specs-greet/adapters/grpcserver"
-	"github.com/quii/go-specs-greet/specifications"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
+// TestGreeterServer checks what's particular to the gRPC server. The
+// specifications are checked against it in specifications/matrix.
 func TestGreeterServer(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
+	t.Parallel()
 	var (
-		port   = "50051"
-		driver = grpcserver.Driver{Addr: fmt.Sprintf("localhost:%s", port)}
+		server = adapters.StartServer(t, "50051", "grpcserver")
+		driver = grpcserver.Driver{Addr: server.Addr, TLS: server.ClientTLS(), Credentials: auth.Credentials{BearerToken: adapters.TestToken()}}
 	)
 
 	t.Cleanup(driver.Close)
-	adapters.StartDockerServer(t, port, "grpcserver")
-	specifications.GreetSpecification(t, &driver)
-	specifications.CurseSpecification(t, &driver)
+
+	t.Run("measures interactions", func(t *testing.T) {
+		_, err := driver.Greet(context.Background(), "Mike")
+		assert.NoError(t, err)
+		_, err = driver.Curse(context.Background(), "Chris")
+		assert.NoError(t, err)
+		adapters.AssertInteractionsMeasured(t, server, interactions.Greeting.Name, interactions.Cursing.Name)
+	})
 }
@@ -0,0 +1,125 @@
+package main_test
+
+import (
+	"context"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/adapters/grpcserver"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+)
+
+func TestOperability(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	server := adapters.StartServer(t, "50051", "grpcserver")
+	creds := insecure.NewCredentials()
+	if tlsConfig := server.ClientTLS(); tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.NewClient(server.Addr, grpc.WithTransportCredentials(creds))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	ctx := context.Background()
+
+	t.Run("reports the greeter as serving", func(t *testing.T) {
+		res, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+			Service: grpcserver.Greeter_ServiceDesc.ServiceName,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.Status)
+	})
+
+	t.Run("lists the greeter through reflection", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}))
+		res, err := stream.Recv()
+		assert.NoError(t, err)
+
+		var services []string
+		for _, service := range res.GetListServicesResponse().GetService() {
+			services = append(services, service.Name)
+		}
+		assert.SliceContains(t, services, grpcserver.Greeter_ServiceDesc.ServiceName)
+	})
+}
+
+func TestGracefulShutdown(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	process := adapters.RunBinary(t, "grpcserver", map[string]string{"ADMIN_PORT": adapters.FreePort(t)})
+	creds := insecure.NewCredentials()
+	if tlsConfig := process.ClientTLS(); tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.NewClient(process.Addr, grpc.WithTransportCredentials(creds))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	watch, err := healthpb.NewHealthClient(conn).Watch(watchCtx, &healthpb.HealthCheckRequest{
+		Service: grpcserver.Greeter_ServiceDesc.ServiceName,
+	})
+	assert.NoError(t, err)
+	awaitHealth(t, watch, healthpb.HealthCheckResponse_SERVING)
+
+	conversation, err := grpcserver.NewGreeterClient(conn).Converse(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Mike", converse(t, conversation, "Mike"))
+
+	assert.NoError(t, process.Signal(syscall.SIGTERM))
+
+	t.Run("reports the greeter as not serving", func(t *testing.T) {
+		awaitHealth(t, watch, healthpb.HealthCheckResponse_NOT_SERVING)
+		stopWatching()
+	})
+
+	t.Run("finishes in-flight RPCs", func(t *testing.T) {
+		assert.Equal(t, "Hello, Chris", converse(t, conversation, "Chris"))
+		assert.NoError(t, conversation.CloseSend())
+		_, err := conversation.Recv()
+		assert.IsError(t, err, io.EOF)
+	})
+
+	t.Run("exits cleanly once they're finished", func(t *testing.T) {
+		assert.NoError(t, process.Wait(t, 5*time.Second))
+	})
+}
+
+func awaitHealth(t *testing.T, watch healthpb.Health_WatchClient, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	for {
+		res, err := watch.Recv()
+		assert.NoError(t, err)
+		if res.Status == want {
+			return
+		}
+	}
+}
+
+func converse(t *testing.T, conversation grpcserver.Greeter_ConverseClient, name string) string {
+	t.Helper()
+	assert.NoError(t, conversation.Send(&grpcserver.GreetRequest{Name: name}))
+	reply, err := conversation.Recv()
+	assert.NoError(t, err)
+	return reply.Message
+}
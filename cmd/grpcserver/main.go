@@ -1,22 +1,118 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/quii/go-specs-greet/adapters/grpcserver"
-	"google.golang.org/grpc"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
 )
 
 func main() {
-	lis, err := net.Listen("tcp", ":50051")
-	if err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
 		log.Fatal(err)
 	}
-	s := grpc.NewServer()
-	grpcserver.RegisterGreeterServer(s, &grpcserver.GreetServer{})
+}
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatal(err)
+func run(ctx context.Context, args []string) (err error) {
+	var (
+		port         = "50051"
+		adminPort    = "9090"
+		drainTimeout = 10 * time.Second
+		logCfg       = bootstrap.DefaultLogConfig()
+		traceCfg     = bootstrap.DefaultTraceConfig()
+		greeterCfg   = bootstrap.DefaultGreeterConfig()
+		authCfg      = bootstrap.AuthConfig{}
+		tlsCfg       = bootstrap.TLSConfig{}
+		flags        = bootstrap.NewFlags("grpcserver", os.Getenv)
+	)
+	flags.StringVar(&port, "port", "PORT", port, "port to listen on")
+	flags.StringVar(&adminPort, "admin-port", "ADMIN_PORT", adminPort, "port to serve "+bootstrap.MetricsPath+" on")
+	flags.DurationVar(&drainTimeout, "drain-timeout", "DRAIN_TIMEOUT", drainTimeout, "how long in-flight RPCs get to finish on shutdown")
+	logCfg.RegisterFlags(flags)
+	traceCfg.RegisterFlags(flags)
+	greeterCfg.RegisterFlags(flags)
+	authCfg.RegisterFlags(flags)
+	tlsCfg.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := errors.Join(greeterCfg.Validate(), authCfg.Validate(), tlsCfg.Validate()); err != nil {
+		return err
+	}
+	logger, err := logCfg.NewLogger(os.Stderr)
+	if err != nil {
+		return err
+	}
+	flushTraces, err := traceCfg.Install("grpcserver", os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, flushTraces(context.Background()))
+	}()
+	service, closeHistory, err := greeterCfg.NewService()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, closeHistory())
+	}()
+
+	authenticator, _, err := authCfg.NewAuthenticator()
+	if err != nil {
+		return err
 	}
+	tlsOpts, err := tlsCfg.GRPCServerOptions()
+	if err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	adminLis, err := net.Listen("tcp", ":"+adminPort)
+	if err != nil {
+		return err
+	}
+
+	metrics := telemetry.NewMetrics()
+	server := grpcserver.NewServer(service, authenticator, append(bootstrap.InstrumentGRPC(logger, metrics), tlsOpts...)...)
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(lis)
+	}()
+	log.Printf("listening on %s", lis.Addr())
+
+	adminCfg := bootstrap.DefaultHTTPConfig(adminPort)
+	adminCfg.ShutdownTimeout = drainTimeout
+	adminServed := make(chan error, 1)
+	go func() {
+		adminServed <- bootstrap.ServeHTTP(ctx, adminLis, adminCfg, bootstrap.AdminHandler(metrics))
+	}()
+
+	select {
+	case err := <-served:
+		return err
+	case err := <-adminServed:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, waiting up to %s for in-flight RPCs", drainTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	return errors.Join(server.Shutdown(shutdownCtx), <-adminServed)
 }
@@ -0,0 +1,68 @@
+package main_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/adapters/grpcserver"
+	"github.com/quii/go-specs-greet/internal/certs"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+func TestGreeterServerOverMutualTLS(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	ca, err := certs.NewCA("go-specs-greet test CA")
+	assert.NoError(t, err)
+	serverCert, err := ca.Issue("grpcserver", "localhost", "127.0.0.1", "::1")
+	assert.NoError(t, err)
+	clientCert, err := ca.Issue(adapters.TestUser)
+	assert.NoError(t, err)
+
+	server := adapters.StartServer(t, "50051", "grpcserver", adapters.WithEnv(map[string]string{
+		"TLS_CERT":      string(serverCert.CertPEM),
+		"TLS_KEY":       string(serverCert.KeyPEM),
+		"TLS_CLIENT_CA": string(ca.CertPEM),
+	}))
+	clientTLS, err := ca.ClientConfig(&clientCert)
+	assert.NoError(t, err)
+	driver := grpcserver.Driver{Addr: server.Addr, TLS: clientTLS}
+	t.Cleanup(driver.Close)
+
+	specifications.GreetSpecification(t, &driver)
+	// The client's certificate is all it needs to authenticate.
+	specifications.CurseSpecification(t, &driver)
+
+	refused := map[string]func(t *testing.T) *grpcserver.Driver{
+		"clients without a certificate": func(t *testing.T) *grpcserver.Driver {
+			noCert, err := ca.ClientConfig(nil)
+			assert.NoError(t, err)
+			return &grpcserver.Driver{Addr: server.Addr, TLS: noCert}
+		},
+		"clients with a certificate from another CA": func(t *testing.T) *grpcserver.Driver {
+			otherCA, err := certs.NewCA("someone else's CA")
+			assert.NoError(t, err)
+			otherCert, err := otherCA.Issue(adapters.TestUser)
+			assert.NoError(t, err)
+			otherTLS, err := ca.ClientConfig(&otherCert)
+			assert.NoError(t, err)
+			return &grpcserver.Driver{Addr: server.Addr, TLS: otherTLS}
+		},
+		"plaintext clients": func(t *testing.T) *grpcserver.Driver {
+			return &grpcserver.Driver{Addr: server.Addr}
+		},
+	}
+	for description, newDriver := range refused {
+		t.Run("refuses "+description, func(t *testing.T) {
+			driver := newDriver(t)
+			t.Cleanup(driver.Close)
+			_, err := driver.Greet(context.Background(), "Mike")
+			assert.Error(t, err)
+		})
+	}
+}
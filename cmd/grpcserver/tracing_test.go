@@ -0,0 +1,25 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/adapters/grpcserver"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+func TestTracing(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	var (
+		spans  = adapters.RecordTraces(t)
+		server = adapters.StartInProcessServer(t, "grpcserver")
+		driver = grpcserver.Driver{Addr: server.Addr, TLS: server.ClientTLS()}
+	)
+
+	t.Cleanup(driver.Close)
+	specifications.LocalisedGreetSpecification(t, &driver)
+	specifications.GreetManySpecification(t, &driver)
+	adapters.AssertTracedPerStep(t, spans.GetSpans())
+}
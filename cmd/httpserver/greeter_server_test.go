@@ -1,31 +1,44 @@
 package main_test
 
 import (
-	"fmt"
-	"net/http"
+	"context"
 	"testing"
 	"time"
 
+	"github.com/alecthomas/assert/v2"
 	"github.com/quii/go-specs-greet/adapters"
 	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/interactions"
 	"github.com/quii/go-specs-greet/specifications"
 )
 
+// TestGreeterServer checks what's particular to the HTTP server. The
+// specifications are checked against it in specifications/matrix.
 func TestGreeterServer(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
+	t.Parallel()
 	var (
-		port   = "8080"
+		server = adapters.StartServer(t, "8080", "httpserver")
 		driver = httpserver.Driver{
-			BaseURL: fmt.Sprintf("http://localhost:%s", port),
-			Client: &http.Client{
-				Timeout: 1 * time.Second,
-			},
+			BaseURL:     server.URL(),
+			Client:      server.HTTPClient(1 * time.Second),
+			Credentials: auth.Credentials{APIKey: adapters.TestAPIKey},
 		}
+		bearer = httpserver.Driver{BaseURL: driver.BaseURL, Client: driver.Client, Credentials: auth.Credentials{BearerToken: adapters.TestToken()}}
 	)
 
-	adapters.StartDockerServer(t, port, "httpserver")
-	specifications.GreetSpecification(t, driver)
-	specifications.CurseSpecification(t, driver)
+	t.Run("curses callers with bearer tokens", func(t *testing.T) {
+		specifications.CurseSpecification(t, bearer)
+	})
+
+	t.Run("measures interactions", func(t *testing.T) {
+		_, err := driver.Greet(context.Background(), "Mike")
+		assert.NoError(t, err)
+		_, err = driver.Curse(context.Background(), "Chris")
+		assert.NoError(t, err)
+		adapters.AssertInteractionsMeasured(t, server, interactions.Greeting.Name, interactions.Cursing.Name)
+	})
 }
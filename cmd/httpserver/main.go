@@ -1,12 +1,71 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
-	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
 )
 
 func main() {
-	log.Fatal(http.ListenAndServe(":8080", httpserver.NewHandler()))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, args []string) (err error) {
+	var (
+		flags      = bootstrap.NewFlags("httpserver", os.Getenv)
+		httpCfg    = bootstrap.DefaultHTTPConfig("8080")
+		logCfg     = bootstrap.DefaultLogConfig()
+		traceCfg   = bootstrap.DefaultTraceConfig()
+		greeterCfg = bootstrap.DefaultGreeterConfig()
+		authCfg    = bootstrap.AuthConfig{}
+	)
+	httpCfg.RegisterFlags(flags)
+	logCfg.RegisterFlags(flags)
+	traceCfg.RegisterFlags(flags)
+	greeterCfg.RegisterFlags(flags)
+	authCfg.RegisterFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := errors.Join(httpCfg.Validate(), greeterCfg.Validate(), authCfg.Validate()); err != nil {
+		return err
+	}
+	logger, err := logCfg.NewLogger(os.Stderr)
+	if err != nil {
+		return err
+	}
+	flushTraces, err := traceCfg.Install("httpserver", os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, flushTraces(context.Background()))
+	}()
+	service, closeHistory, err := greeterCfg.NewService()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, closeHistory())
+	}()
+
+	authenticator, _, err := authCfg.NewAuthenticator()
+	if err != nil {
+		return err
+	}
+
+	handler := bootstrap.InstrumentHTTP(logger, telemetry.NewMetrics(), httpserver.NewHandler(service, authenticator))
+	return bootstrap.ListenAndServeHTTP(ctx, httpCfg, handler)
 }
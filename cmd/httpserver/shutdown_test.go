@@ -0,0 +1,19 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	process := adapters.RunBinary(t, "httpserver", nil)
+	got := adapters.AssertDrainsOnSIGTERM(t, process, "/greet", "application/json", `{"name":"Mike"}`)
+	assert.Equal(t, "Hello, Mike", got)
+}
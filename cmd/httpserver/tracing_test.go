@@ -0,0 +1,27 @@
+package main_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+func TestTracing(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	var (
+		spans  = adapters.RecordTraces(t)
+		server = adapters.StartInProcessServer(t, "httpserver")
+		driver = httpserver.Driver{
+			BaseURL: server.URL(),
+			Client:  server.HTTPClient(1 * time.Second),
+		}
+	)
+
+	specifications.LocalisedGreetSpecification(t, driver)
+	adapters.AssertTracedPerStep(t, spans.GetSpans())
+}
@@ -0,0 +1,19 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+
+	process := adapters.RunBinary(t, "webserver", nil)
+	got := adapters.AssertDrainsOnSIGTERM(t, process, "/greet", "application/x-www-form-urlencoded", "name=Mike")
+	assert.Contains(t, got, "Hello, Mike")
+}
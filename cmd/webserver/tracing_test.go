@@ -0,0 +1,27 @@
+package main_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/adapters/webserver"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+func TestTracing(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	var (
+		spans           = adapters.RecordTraces(t)
+		server          = adapters.StartInProcessServer(t, "webserver")
+		driver, cleanup = webserver.NewDriver(server.URL(), webserver.WithRootCAs(server.RootCAs))
+	)
+
+	t.Cleanup(func() {
+		assert.NoError(t, cleanup())
+	})
+	specifications.LocalisedGreetSpecification(t, driver)
+	adapters.AssertTracedPerStep(t, spans.GetSpans())
+}
@@ -1,29 +1,36 @@
 package main_test
 
 import (
-	"fmt"
+	"context"
 	"testing"
 
 	"github.com/alecthomas/assert/v2"
 	"github.com/quii/go-specs-greet/adapters"
 	"github.com/quii/go-specs-greet/adapters/webserver"
-	"github.com/quii/go-specs-greet/specifications"
+	"github.com/quii/go-specs-greet/domain/interactions"
 )
 
+// TestGreeterWeb checks what's particular to the web server. The
+// specifications are checked against it in specifications/matrix.
 func TestGreeterWeb(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
 	}
+	t.Parallel()
 	var (
-		port            = "8081"
-		driver, cleanup = webserver.NewDriver(fmt.Sprintf("http://localhost:%s", port))
+		server          = adapters.StartServer(t, "8081", "webserver")
+		driver, cleanup = webserver.NewDriver(server.URL(), webserver.WithRootCAs(server.RootCAs), webserver.WithAPIKey(adapters.TestAPIKey))
 	)
 
 	t.Cleanup(func() {
 		assert.NoError(t, cleanup())
 	})
 
-	adapters.StartDockerServer(t, port, "webserver")
-	specifications.GreetSpecification(t, driver)
-	specifications.CurseSpecification(t, driver)
+	t.Run("measures interactions", func(t *testing.T) {
+		_, err := driver.Greet(context.Background(), "Mike")
+		assert.NoError(t, err)
+		_, err = driver.Curse(context.Background(), "Chris")
+		assert.NoError(t, err)
+		adapters.AssertInteractionsMeasured(t, server, interactions.Greeting.Name, interactions.Cursing.Name)
+	})
 }
@@ -0,0 +1,64 @@
+// Package auth checks the credentials callers present to prove who they are.
+package auth
+
+import (
+	"crypto/subtle"
+
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// Credentials are what a caller presented. Either may be empty.
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// Authenticator returns the user credentials belong to. It returns "" and no
+// error when credentials don't include anything it checks, and
+// interactions.ErrInvalidCredentials when they do but it doesn't accept them.
+type Authenticator interface {
+	Authenticate(credentials Credentials) (user string, err error)
+}
+
+// APIKeys authenticates static API keys, mapping each key to its user.
+type APIKeys map[string]string
+
+func (k APIKeys) Authenticate(credentials Credentials) (string, error) {
+	if credentials.APIKey == "" {
+		return "", nil
+	}
+	// Compare against every key, so how long this takes doesn't give away
+	// how close a guess was.
+	var user string
+	for key, owner := range k {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(credentials.APIKey)) == 1 {
+			user = owner
+		}
+	}
+	if user == "" {
+		return "", interactions.ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// Any authenticates with each of its authenticators in turn, returning the
+// first user one of them recognises. Credentials none of them check are
+// refused, as is any credential an authenticator refuses.
+type Any []Authenticator
+
+func (a Any) Authenticate(credentials Credentials) (string, error) {
+	var user string
+	for _, authenticator := range a {
+		u, err := authenticator.Authenticate(credentials)
+		if err != nil {
+			return "", err
+		}
+		if user == "" {
+			user = u
+		}
+	}
+	if user == "" && credentials != (Credentials{}) {
+		return "", interactions.ErrInvalidCredentials
+	}
+	return user, nil
+}
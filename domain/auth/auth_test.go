@@ -0,0 +1,105 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+func TestAPIKeys(t *testing.T) {
+	keys := auth.APIKeys{"s3cret": "alice", "hunter2": "bob"}
+
+	user, err := keys.Authenticate(auth.Credentials{APIKey: "hunter2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", user)
+
+	_, err = keys.Authenticate(auth.Credentials{APIKey: "hunter3"})
+	assert.IsError(t, err, interactions.ErrInvalidCredentials)
+
+	user, err = keys.Authenticate(auth.Credentials{BearerToken: "not a key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "", user)
+}
+
+func TestTokens(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	newTokens := func(secret string) *auth.Tokens {
+		tokens := auth.NewTokens([]byte(secret))
+		tokens.Now = func() time.Time { return now }
+		return tokens
+	}
+	tokens := newTokens("correct horse battery staple")
+
+	t.Run("verifies the tokens it issues", func(t *testing.T) {
+		user, err := tokens.Authenticate(auth.Credentials{BearerToken: tokens.Issue("alice", time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", user)
+	})
+
+	t.Run("refuses expired tokens", func(t *testing.T) {
+		expired := newTokens("correct horse battery staple")
+		expired.Now = func() time.Time { return now.Add(-time.Hour) }
+		_, err := tokens.Verify(expired.Issue("alice", time.Hour))
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	t.Run("refuses tokens signed with another secret", func(t *testing.T) {
+		_, err := tokens.Verify(newTokens("Tr0ub4dor&3").Issue("alice", time.Hour))
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	t.Run("refuses tampered tokens", func(t *testing.T) {
+		parts := strings.Split(tokens.Issue("alice", time.Hour), ".")
+		forged := strings.Split(newTokens("Tr0ub4dor&3").Issue("mallory", time.Hour), ".")
+		_, err := tokens.Verify(parts[0] + "." + forged[1] + "." + parts[2])
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	t.Run("refuses unsigned tokens", func(t *testing.T) {
+		// {"alg":"none"}.{"sub":"mallory","exp":9999999999}.
+		_, err := tokens.Verify("eyJhbGciOiJub25lIn0.eyJzdWIiOiJtYWxsb3J5IiwiZXhwIjo5OTk5OTk5OTk5fQ.")
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	t.Run("refuses tokens for another audience", func(t *testing.T) {
+		sessions := newTokens("correct horse battery staple")
+		sessions.Audience = "sessions"
+		_, err := tokens.Verify(sessions.Issue("alice", time.Hour))
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+		_, err = sessions.Verify(tokens.Issue("alice", time.Hour))
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+
+		user, err := sessions.Verify(sessions.Issue("alice", time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", user)
+	})
+
+	t.Run("ignores credentials without a token", func(t *testing.T) {
+		user, err := tokens.Authenticate(auth.Credentials{APIKey: "s3cret"})
+		assert.NoError(t, err)
+		assert.Equal(t, "", user)
+	})
+}
+
+func TestAny(t *testing.T) {
+	tokens := auth.NewTokens([]byte("correct horse battery staple"))
+	either := auth.Any{auth.APIKeys{"s3cret": "alice"}, tokens}
+
+	user, err := either.Authenticate(auth.Credentials{BearerToken: tokens.Issue("bob", time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob", user)
+
+	_, err = either.Authenticate(auth.Credentials{APIKey: "s3cret", BearerToken: "forged"})
+	assert.IsError(t, err, interactions.ErrInvalidCredentials)
+
+	user, err = either.Authenticate(auth.Credentials{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", user)
+
+	_, err = auth.Any{}.Authenticate(auth.Credentials{BearerToken: tokens.Issue("bob", time.Hour)})
+	assert.IsError(t, err, interactions.ErrInvalidCredentials)
+}
@@ -0,0 +1,83 @@
+// Package greeter is what adapters call to interact with people: it applies
+// the rules that hold whichever adapter the call came through, and keeps the
+// history of what was said.
+package greeter
+
+import (
+	"context"
+	"time"
+
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/domain/moderation"
+	"github.com/quii/go-specs-greet/domain/ratelimit"
+	"golang.org/x/text/language"
+)
+
+type Service struct {
+	History history.Repository
+	// Limiter throttles Throttled interactions per caller. Nil means they
+	// aren't limited.
+	Limiter *ratelimit.Limiter
+	// Moderation masks or refuses names before they are replied to or
+	// recorded. Nil means every name is allowed.
+	Moderation *moderation.Policy
+	// Now is when interactions happen, time.Now when nil.
+	Now func() time.Time
+}
+
+func NewService(history history.Repository, limiter *ratelimit.Limiter) *Service {
+	return &Service{History: history, Limiter: limiter}
+}
+
+// Call is someone asking an adapter for an interaction.
+type Call struct {
+	Interaction interactions.Interaction
+	Locale      language.Tag
+	Name        string
+	// Caller identifies who is asking, e.g. their IP address, so each caller
+	// is throttled separately.
+	Caller string
+	// User is who the caller authenticated as, or "" if they didn't.
+	User string
+	// Adapter is what the call came through, e.g. "httpserver".
+	Adapter string
+}
+
+// Interact replies to call and records it in the history, both with the name
+// as moderated. Authenticated interactions are refused to anonymous callers,
+// and callers making throttled interactions too often get a
+// *interactions.ThrottledError.
+func (s *Service) Interact(ctx context.Context, call Call) (string, error) {
+	if call.Interaction.Authenticated && call.User == "" {
+		return "", interactions.ErrUnauthenticated
+	}
+	if call.Interaction.Throttled && s.Limiter != nil {
+		if ok, retryAfter := s.Limiter.Allow(call.Caller); !ok {
+			return "", &interactions.ThrottledError{RetryAfter: retryAfter}
+		}
+	}
+
+	name, err := interactions.NormaliseName(call.Name)
+	if err != nil {
+		return "", err
+	}
+	if s.Moderation != nil {
+		if name, err = s.Moderation.Moderate(name); err != nil {
+			return "", err
+		}
+	}
+	recorder := history.Recorder{Repository: s.History, Adapter: call.Adapter, Now: s.Now}
+	return recorder.Reply(ctx, call.Interaction, call.Locale, name)
+}
+
+// ListHistory returns the entries matching query, most recent first. Its
+// name is normalised like the names that were recorded.
+func (s *Service) ListHistory(ctx context.Context, query history.Query) ([]history.Entry, error) {
+	name, err := interactions.NormaliseName(query.Name)
+	if err != nil {
+		return nil, err
+	}
+	query.Name = name
+	return s.History.List(ctx, query)
+}
@@ -0,0 +1,121 @@
+package greeter_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/domain/moderation"
+	"github.com/quii/go-specs-greet/domain/ratelimit"
+	"github.com/quii/go-specs-greet/specifications"
+	"golang.org/x/text/language"
+)
+
+func TestService(t *testing.T) {
+	var (
+		ctx = context.Background()
+		at  = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	)
+	newService := func(limiter *ratelimit.Limiter) *greeter.Service {
+		service := greeter.NewService(history.NewInMemory(), limiter)
+		service.Now = func() time.Time { return at }
+		return service
+	}
+	call := func(interaction interactions.Interaction, caller string, name string) greeter.Call {
+		return greeter.Call{Interaction: interaction, Locale: language.English, Name: name, Caller: caller, User: "alice", Adapter: "test"}
+	}
+
+	t.Run("records the normalised name of successful interactions", func(t *testing.T) {
+		service := newService(nil)
+		got, err := service.Interact(ctx, call(interactions.Greeting, "127.0.0.1", "  Zoë "))
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, Zoë", got)
+
+		entries, err := service.ListHistory(ctx, history.Query{Name: "Zoë"})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{{Interaction: "greet", Name: "Zoë", Adapter: "test", At: at}}, entries)
+	})
+
+	t.Run("doesn't record interactions that fail", func(t *testing.T) {
+		service := newService(nil)
+		_, err := service.Interact(ctx, call(interactions.Cursing, "127.0.0.1", "Mi\u0085ke"))
+		assert.IsError(t, err, interactions.ErrNameHasControlCharacters)
+
+		entries, err := service.ListHistory(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{}, entries)
+	})
+
+	t.Run("meets the throttling specification", func(t *testing.T) {
+		service := newService(ratelimit.NewLimiter(1, 5))
+		interact := func(interaction interactions.Interaction) func(string) (string, error) {
+			return func(name string) (string, error) {
+				return service.Interact(ctx, call(interaction, "127.0.0.1", name))
+			}
+		}
+		specifications.CurseThrottleSpecification(t, struct {
+			specifications.GreetAdapter
+			specifications.CurseAdapter
+		}{interact(interactions.Greeting), interact(interactions.Cursing)})
+	})
+
+	t.Run("meets the authentication specification", func(t *testing.T) {
+		service := newService(ratelimit.NewLimiter(1, 1))
+		interact := func(interaction interactions.Interaction) func(string) (string, error) {
+			return func(name string) (string, error) {
+				anonymous := call(interaction, "127.0.0.1", name)
+				anonymous.User = ""
+				return service.Interact(ctx, anonymous)
+			}
+		}
+		specifications.CurseAuthenticationSpecification(t, struct {
+			specifications.GreetAdapter
+			specifications.CurseAdapter
+		}{interact(interactions.Greeting), interact(interactions.Cursing)})
+
+		_, err := service.Interact(ctx, call(interactions.Cursing, "127.0.0.1", "Chris"))
+		assert.NoError(t, err, "anonymous curses shouldn't use up the caller's limit")
+	})
+
+	t.Run("meets the moderation specification", func(t *testing.T) {
+		service := newService(nil)
+		service.Moderation = moderation.NewPolicy(moderation.Reject, moderation.DefaultBlocklist, moderation.DefaultAllowlist)
+		specifications.ModerationSpecification(t, specifications.GreetAdapter(func(name string) (string, error) {
+			return service.Interact(ctx, call(interactions.Greeting, "127.0.0.1", name))
+		}))
+	})
+
+	t.Run("records names as masked", func(t *testing.T) {
+		service := newService(nil)
+		service.Moderation = moderation.NewPolicy(moderation.Mask, moderation.DefaultBlocklist, moderation.DefaultAllowlist)
+		got, err := service.Interact(ctx, call(interactions.Cursing, "127.0.0.1", "Mike Shithead"))
+		assert.NoError(t, err)
+		assert.Equal(t, "Go to hell, Mike ********!", got)
+
+		entries, err := service.ListHistory(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{{Interaction: "curse", Name: "Mike ********", Adapter: "test", At: at}}, entries)
+	})
+
+	t.Run("throttles throttled interactions per caller", func(t *testing.T) {
+		service := newService(ratelimit.NewLimiter(1, 1))
+		_, err := service.Interact(ctx, call(interactions.Cursing, "alice", "Chris"))
+		assert.NoError(t, err)
+
+		_, err = service.Interact(ctx, call(interactions.Cursing, "alice", "Chris"))
+		assert.IsError(t, err, interactions.ErrTooManyRequests)
+		var throttled *interactions.ThrottledError
+		assert.True(t, errors.As(err, &throttled))
+		assert.True(t, throttled.RetryAfter > 0)
+
+		_, err = service.Interact(ctx, call(interactions.Cursing, "bob", "Chris"))
+		assert.NoError(t, err)
+		_, err = service.Interact(ctx, call(interactions.Greeting, "alice", "Chris"))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package history
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultLimit is how many entries a Query without a Limit returns.
+	DefaultLimit = 50
+	// MaxLimit is the most entries one Query can return.
+	MaxLimit = 1000
+)
+
+// Entry is one interaction someone had with the greeter.
+type Entry struct {
+	Interaction string
+	// Name is who it was said to, as normalised by interactions.NormaliseName.
+	Name string
+	// Adapter is what it was said through, e.g. "httpserver".
+	Adapter string
+	At      time.Time
+}
+
+// Query picks which entries List returns. Empty fields match every entry.
+type Query struct {
+	Name        string
+	Interaction string
+	// Limit caps how many entries are returned, see DefaultLimit and MaxLimit.
+	Limit int
+}
+
+// Matches reports whether entry is one the query asks for.
+func (q Query) Matches(entry Entry) bool {
+	return (q.Name == "" || q.Name == entry.Name) &&
+		(q.Interaction == "" || q.Interaction == entry.Interaction)
+}
+
+// MaxEntries is how many entries the query may return.
+func (q Query) MaxEntries() int {
+	switch {
+	case q.Limit <= 0:
+		return DefaultLimit
+	case q.Limit > MaxLimit:
+		return MaxLimit
+	default:
+		return q.Limit
+	}
+}
+
+// Repository is where the history of interactions is kept.
+type Repository interface {
+	Record(ctx context.Context, entry Entry) error
+	// List returns the entries matching query, most recent first.
+	List(ctx context.Context, query Query) ([]Entry, error)
+}
@@ -0,0 +1,96 @@
+// Package historytest checks history.Repository implementations behave alike.
+package historytest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+// RepositoryContract is what every history.Repository must do. newRepository
+// returns an empty repository.
+func RepositoryContract(t *testing.T, newRepository func(t *testing.T) history.Repository) {
+	ctx := context.Background()
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	entries := []history.Entry{
+		{Interaction: "greet", Name: "Mike", Adapter: "httpserver", At: at},
+		{Interaction: "curse", Name: "Chris", Adapter: "grpcserver", At: at.Add(time.Second)},
+		{Interaction: "curse", Name: "Mike", Adapter: "webserver", At: at.Add(2 * time.Second)},
+	}
+	record := func(t *testing.T, repository history.Repository, entries ...history.Entry) {
+		for _, entry := range entries {
+			assert.NoError(t, repository.Record(ctx, entry))
+		}
+	}
+
+	t.Run("is empty to begin with", func(t *testing.T) {
+		got, err := newRepository(t).List(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{}, got)
+	})
+
+	t.Run("lists entries most recent first", func(t *testing.T) {
+		repository := newRepository(t)
+		record(t, repository, entries...)
+
+		got, err := repository.List(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{entries[2], entries[1], entries[0]}, got)
+	})
+
+	t.Run("filters by name and interaction", func(t *testing.T) {
+		repository := newRepository(t)
+		record(t, repository, entries...)
+
+		got, err := repository.List(ctx, history.Query{Name: "Mike"})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{entries[2], entries[0]}, got)
+
+		got, err = repository.List(ctx, history.Query{Name: "Mike", Interaction: "greet"})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{entries[0]}, got)
+	})
+
+	t.Run("returns no more than the limit", func(t *testing.T) {
+		repository := newRepository(t)
+		for i := range history.MaxLimit + 1 {
+			record(t, repository, history.Entry{Interaction: "greet", Name: fmt.Sprint(i), At: at})
+		}
+
+		got, err := repository.List(ctx, history.Query{Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(got))
+		assert.Equal(t, fmt.Sprint(history.MaxLimit), got[0].Name)
+
+		got, err = repository.List(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, history.DefaultLimit, len(got))
+
+		got, err = repository.List(ctx, history.Query{Limit: history.MaxLimit + 1})
+		assert.NoError(t, err)
+		assert.Equal(t, history.MaxLimit, len(got))
+	})
+
+	t.Run("keeps entries safe from concurrent use", func(t *testing.T) {
+		repository := newRepository(t)
+		done := make(chan struct{})
+		for range 10 {
+			go func() {
+				defer func() { done <- struct{}{} }()
+				_ = repository.Record(ctx, entries[0])
+				_, _ = repository.List(ctx, history.Query{})
+			}()
+		}
+		for range 10 {
+			<-done
+		}
+
+		got, err := repository.List(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, len(got))
+	})
+}
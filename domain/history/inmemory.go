@@ -0,0 +1,47 @@
+package history
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemory is a Repository that forgets everything when the process exits.
+type InMemory struct {
+	mu      sync.RWMutex
+	entries []Entry
+}
+
+func NewInMemory() *InMemory {
+	return &InMemory{}
+}
+
+func (m *InMemory) Record(ctx context.Context, entry Entry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *InMemory) List(ctx context.Context, query Query) ([]Entry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return Newest(m.entries, query), nil
+}
+
+// Newest returns the entries matching query, most recent first, from entries
+// in the order they were recorded.
+func Newest(entries []Entry, query Query) []Entry {
+	found := []Entry{}
+	for i := len(entries) - 1; i >= 0 && len(found) < query.MaxEntries(); i-- {
+		if query.Matches(entries[i]) {
+			found = append(found, entries[i])
+		}
+	}
+	return found
+}
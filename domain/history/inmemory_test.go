@@ -0,0 +1,14 @@
+package history_test
+
+import (
+	"testing"
+
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/history/historytest"
+)
+
+func TestInMemory(t *testing.T) {
+	historytest.RepositoryContract(t, func(t *testing.T) history.Repository {
+		return history.NewInMemory()
+	})
+}
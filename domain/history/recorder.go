@@ -0,0 +1,48 @@
+package history
+
+import (
+	"context"
+	"time"
+
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"golang.org/x/text/language"
+)
+
+// Recorder performs interactions on behalf of an adapter and records each one
+// that succeeds.
+type Recorder struct {
+	Repository Repository
+	Adapter    string
+	// Now is when interactions happen, time.Now when nil.
+	Now func() time.Time
+}
+
+func NewRecorder(repository Repository, adapter string) Recorder {
+	return Recorder{Repository: repository, Adapter: adapter}
+}
+
+// Reply is interaction.Reply, recorded.
+func (r Recorder) Reply(ctx context.Context, interaction interactions.Interaction, locale language.Tag, name string) (string, error) {
+	message, err := interaction.Reply(locale, name)
+	if err != nil {
+		return "", err
+	}
+	name, _ = interactions.NormaliseName(name)
+	err = r.Repository.Record(ctx, Entry{
+		Interaction: interaction.Name,
+		Name:        name,
+		Adapter:     r.Adapter,
+		At:          r.now(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return message, nil
+}
+
+func (r Recorder) now() time.Time {
+	if r.Now == nil {
+		return time.Now().UTC()
+	}
+	return r.Now()
+}
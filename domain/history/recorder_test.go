@@ -0,0 +1,40 @@
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"golang.org/x/text/language"
+)
+
+func TestRecorder(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		at       = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		repo     = history.NewInMemory()
+		recorder = history.Recorder{Repository: repo, Adapter: "test", Now: func() time.Time { return at }}
+	)
+
+	t.Run("records the normalised name of successful interactions", func(t *testing.T) {
+		got, err := recorder.Reply(ctx, interactions.Greeting, language.English, "  Zoë ")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, Zoë", got)
+
+		entries, err := repo.List(ctx, history.Query{})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{{Interaction: "greet", Name: "Zoë", Adapter: "test", At: at}}, entries)
+	})
+
+	t.Run("doesn't record interactions that fail", func(t *testing.T) {
+		_, err := recorder.Reply(ctx, interactions.Cursing, language.English, "Mi\u0085ke")
+		assert.IsError(t, err, interactions.ErrNameHasControlCharacters)
+
+		entries, err := repo.List(ctx, history.Query{Interaction: "curse"})
+		assert.NoError(t, err)
+		assert.Equal(t, []history.Entry{}, entries)
+	})
+}
@@ -1,7 +1,11 @@
 package interactions
 
-import "fmt"
+import "golang.org/x/text/language"
 
-func Curse(name string) string {
-	return fmt.Sprintf("Go to hell, %s!", name)
+func Curse(name string) (string, error) {
+	return CurseIn(DefaultLocale, name)
+}
+
+func CurseIn(locale language.Tag, name string) (string, error) {
+	return Cursing.Reply(locale, name)
 }
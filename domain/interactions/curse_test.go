@@ -3,13 +3,21 @@ package interactions_test
 import (
 	"testing"
 
+	"github.com/alecthomas/assert/v2"
 	"github.com/quii/go-specs-greet/domain/interactions"
-	"github.com/quii/go-specs-greet/specifications"
+	"golang.org/x/text/language"
 )
 
 func TestCurse(t *testing.T) {
-	specifications.CurseSpecification(
-		t,
-		specifications.CurseAdapter(interactions.Curse),
-	)
+	t.Run("curses the name", func(t *testing.T) {
+		got, err := interactions.Curse("Chris")
+		assert.NoError(t, err)
+		assert.Equal(t, "Go to hell, Chris!", got)
+	})
+
+	t.Run("curses in the locale's language", func(t *testing.T) {
+		got, err := interactions.CurseIn(language.German, "Chris")
+		assert.NoError(t, err)
+		assert.Equal(t, "Fahr zur Hölle, Chris!", got)
+	})
 }
@@ -0,0 +1,131 @@
+package interactions
+
+import (
+	"fmt"
+	"time"
+)
+
+// Kind groups domain errors by what went wrong, which is what adapters map to
+// their own status codes.
+type Kind int
+
+const (
+	// KindInvalid means the caller sent something that will never succeed.
+	KindInvalid Kind = iota + 1
+	// KindNotFound means the caller asked for something that doesn't exist.
+	KindNotFound
+	// KindThrottled means the caller has to wait before trying again.
+	KindThrottled
+	// KindRefused means the request was understood but the moderation policy
+	// won't allow it.
+	KindRefused
+	// KindUnauthenticated means the caller has to prove who they are first.
+	KindUnauthenticated
+)
+
+// Error is a domain error that adapters send over the wire by Code and rebuild
+// on the other side with LookupError, so errors.Is gives the same answer
+// whichever adapter reported it.
+type Error struct {
+	Kind   Kind
+	Code   string
+	Detail string
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+// Is matches any *Error with the same Code, so a rebuilt error is equal to
+// the sentinel it was built from.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+var (
+	ErrNameTooLong = &Error{
+		Kind:   KindInvalid,
+		Code:   "name_too_long",
+		Detail: fmt.Sprintf("name must be at most %d characters", MaxNameLength),
+	}
+	ErrNameNotUTF8 = &Error{
+		Kind:   KindInvalid,
+		Code:   "name_not_utf8",
+		Detail: "name must be valid UTF-8",
+	}
+	ErrNameHasControlCharacters = &Error{
+		Kind:   KindInvalid,
+		Code:   "name_has_control_characters",
+		Detail: "name must not contain control characters",
+	}
+	ErrUnknownInteraction = &Error{
+		Kind:   KindNotFound,
+		Code:   "unknown_interaction",
+		Detail: "no such interaction",
+	}
+	ErrTooManyRequests = &Error{
+		Kind:   KindThrottled,
+		Code:   "too_many_requests",
+		Detail: "too many requests, slow down and try again later",
+	}
+	ErrNameNotAllowed = &Error{
+		Kind:   KindRefused,
+		Code:   "name_not_allowed",
+		Detail: "name contains a word that isn't allowed",
+	}
+	ErrNameMixesScripts = &Error{
+		Kind:   KindRefused,
+		Code:   "name_mixes_scripts",
+		Detail: "name mixes lookalike letters from different alphabets",
+	}
+	ErrUnauthenticated = &Error{
+		Kind:   KindUnauthenticated,
+		Code:   "unauthenticated",
+		Detail: "you need to authenticate to do that",
+	}
+	ErrInvalidCredentials = &Error{
+		Kind:   KindUnauthenticated,
+		Code:   "invalid_credentials",
+		Detail: "credentials are invalid or have expired",
+	}
+)
+
+// ThrottledError is ErrTooManyRequests along with how long the caller should
+// wait before trying again.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrTooManyRequests.Detail, e.RetryAfter)
+}
+
+func (e *ThrottledError) Unwrap() error {
+	return ErrTooManyRequests
+}
+
+var knownErrors = map[string]*Error{}
+
+func init() {
+	for _, err := range []*Error{
+		ErrNameTooLong,
+		ErrNameNotUTF8,
+		ErrNameHasControlCharacters,
+		ErrUnknownInteraction,
+		ErrTooManyRequests,
+		ErrNameNotAllowed,
+		ErrNameMixesScripts,
+		ErrUnauthenticated,
+		ErrInvalidCredentials,
+	} {
+		knownErrors[err.Code] = err
+	}
+}
+
+// LookupError returns the domain error identified by code, or false if code
+// isn't one this version of the domain knows about.
+func LookupError(code string) (*Error, bool) {
+	err, ok := knownErrors[code]
+	return err, ok
+}
@@ -0,0 +1,45 @@
+package interactions_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+func FuzzGreet(f *testing.F) {
+	f.Add("Mike")
+	fuzzInteraction(f, interactions.Greet)
+}
+
+func FuzzCurse(f *testing.F) {
+	f.Add("Chris")
+	fuzzInteraction(f, interactions.Curse)
+}
+
+// fuzzInteraction checks interact either replies with the normalised name,
+// as valid UTF-8, or refuses the name with the same error NormaliseName does.
+// The seed corpus is in testdata/fuzz.
+func fuzzInteraction(f *testing.F, interact func(name string) (string, error)) {
+	f.Fuzz(func(t *testing.T, name string) {
+		got, err := interact(name)
+		want, normaliseErr := interactions.NormaliseName(name)
+		if normaliseErr != nil {
+			if !errors.Is(err, normaliseErr) {
+				t.Fatalf("interact(%q) = %q, %v, want %v", name, got, err, normaliseErr)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("interact(%q) failed: %v", name, err)
+		}
+		if !utf8.ValidString(got) {
+			t.Fatalf("interact(%q) = %q, which isn't valid UTF-8", name, got)
+		}
+		if !strings.Contains(got, want) {
+			t.Fatalf("interact(%q) = %q, which doesn't contain %q", name, got, want)
+		}
+	})
+}
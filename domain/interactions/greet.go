@@ -1,10 +1,11 @@
 package interactions
 
-import "fmt"
+import "golang.org/x/text/language"
 
-func Greet(name string) string {
-	if name == "" {
-		name = "World"
-	}
-	return fmt.Sprintf("Hello, %s", name)
+func Greet(name string) (string, error) {
+	return GreetIn(DefaultLocale, name)
+}
+
+func GreetIn(locale language.Tag, name string) (string, error) {
+	return Greeting.Reply(locale, name)
 }
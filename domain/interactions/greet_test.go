@@ -5,16 +5,19 @@ import (
 
 	"github.com/alecthomas/assert/v2"
 	"github.com/quii/go-specs-greet/domain/interactions"
-	"github.com/quii/go-specs-greet/specifications"
+	"golang.org/x/text/language"
 )
 
 func TestGreet(t *testing.T) {
-	specifications.GreetSpecification(
-		t,
-		specifications.GreetAdapter(interactions.Greet),
-	)
-
 	t.Run("default name to world if it's an empty string", func(t *testing.T) {
-		assert.Equal(t, "Hello, World", interactions.Greet(""))
+		got, err := interactions.Greet("")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, World", got)
+	})
+
+	t.Run("default name to world in the locale's language", func(t *testing.T) {
+		got, err := interactions.GreetIn(language.German, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hallo, Welt", got)
 	})
 }
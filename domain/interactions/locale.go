@@ -0,0 +1,92 @@
+package interactions
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is the language every other catalogue falls back to.
+var DefaultLocale = language.English
+
+var (
+	//go:embed locales/*.json
+	catalogueFiles embed.FS
+
+	catalogues, locales = mustLoadCatalogues()
+	matcher             = language.NewMatcher(locales)
+)
+
+type catalogue map[string]string
+
+// Locales returns every language there is a message catalogue for, with
+// DefaultLocale first.
+func Locales() []language.Tag {
+	return append([]language.Tag(nil), locales...)
+}
+
+// ParseLocale picks the best supported language for an Accept-Language style
+// list such as "fr-CA, de;q=0.8". Anything unparseable or unsupported resolves
+// to DefaultLocale.
+func ParseLocale(acceptLanguage string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	return match(tags...)
+}
+
+// LanguageName is the name of locale in its own language, e.g. "Français".
+func LanguageName(locale language.Tag) string {
+	return message(locale, "language")
+}
+
+func match(tags ...language.Tag) language.Tag {
+	_, i, _ := matcher.Match(tags...)
+	return locales[i]
+}
+
+// message looks key up in the closest catalogue to locale (so fr-CA reads from
+// fr), falling back to DefaultLocale for anything that catalogue is missing.
+func message(locale language.Tag, key string) string {
+	if m, ok := catalogues[match(locale)][key]; ok {
+		return m
+	}
+	return catalogues[DefaultLocale][key]
+}
+
+func mustLoadCatalogues() (map[language.Tag]catalogue, []language.Tag) {
+	files, err := catalogueFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	all := map[language.Tag]catalogue{}
+	tags := []language.Tag{DefaultLocale}
+	for _, file := range files {
+		tag := language.Make(strings.TrimSuffix(file.Name(), path.Ext(file.Name())))
+
+		raw, err := catalogueFiles.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		var c catalogue
+		if err := json.Unmarshal(raw, &c); err != nil {
+			panic(fmt.Errorf("parsing catalogue %s: %w", file.Name(), err))
+		}
+
+		all[tag] = c
+		if tag != DefaultLocale {
+			tags = append(tags, tag)
+		}
+	}
+
+	if _, ok := all[DefaultLocale]; !ok {
+		panic(fmt.Errorf("no catalogue for default locale %s", DefaultLocale))
+	}
+	return all, tags
+}
@@ -0,0 +1,33 @@
+package interactions
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxNameLength is the most characters (runes, after normalisation) a name
+// may have.
+const MaxNameLength = 100
+
+// NormaliseName validates name and returns it trimmed of surrounding
+// whitespace and in Unicode NFC, so visually identical names compare equal.
+func NormaliseName(name string) (string, error) {
+	if len(name) > MaxNameLength*utf8.UTFMax {
+		return "", ErrNameTooLong
+	}
+	if !utf8.ValidString(name) {
+		return "", ErrNameNotUTF8
+	}
+
+	name = norm.NFC.String(strings.TrimSpace(name))
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", ErrNameTooLong
+	}
+	if strings.IndexFunc(name, unicode.IsControl) != -1 {
+		return "", ErrNameHasControlCharacters
+	}
+	return name, nil
+}
@@ -0,0 +1,41 @@
+package interactions_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+func TestNormaliseName(t *testing.T) {
+	t.Run("trims whitespace and composes to NFC", func(t *testing.T) {
+		got, err := interactions.NormaliseName("  Zoe\u0308 ")
+		assert.NoError(t, err)
+		assert.Equal(t, "Zo\u00eb", got)
+	})
+
+	t.Run("length is counted in characters, not bytes", func(t *testing.T) {
+		_, err := interactions.NormaliseName(strings.Repeat("ë", interactions.MaxNameLength))
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		for name, want := range map[string]error{
+			strings.Repeat("a", interactions.MaxNameLength+1): interactions.ErrNameTooLong,
+			strings.Repeat("a", 1<<20):                        interactions.ErrNameTooLong,
+			"Mi\xffke":                                        interactions.ErrNameNotUTF8,
+			"Mi\x00ke":                                        interactions.ErrNameHasControlCharacters,
+			"Mike\nDELETE":                                    interactions.ErrNameHasControlCharacters,
+		} {
+			_, err := interactions.NormaliseName(name)
+			assert.IsError(t, err, want)
+		}
+	})
+
+	t.Run("errors rebuilt from their code match the original", func(t *testing.T) {
+		rebuilt, ok := interactions.LookupError(interactions.ErrNameTooLong.Code)
+		assert.True(t, ok)
+		assert.IsError(t, &interactions.Error{Code: rebuilt.Code}, interactions.ErrNameTooLong)
+	})
+}
@@ -0,0 +1,112 @@
+package interactions
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Interaction is something the domain can say to a name. Its reply is the
+// message catalogue entry under Name (e.g. "Hello, %s"), and the button or
+// legend adapters show for it is the entry under Name+".label".
+type Interaction struct {
+	Name string
+	// Anonymous is the catalogue key of the name used when none is given,
+	// e.g. "world". Interactions without one reply to the empty name as-is.
+	Anonymous string
+	// Throttled interactions are rate limited per caller.
+	Throttled bool
+	// Authenticated interactions are refused to callers who haven't proved
+	// who they are.
+	Authenticated bool
+}
+
+var (
+	Greeting = Interaction{Name: "greet", Anonymous: "world"}
+	Cursing  = Interaction{Name: "curse", Throttled: true, Authenticated: true}
+	Welcome  = Interaction{Name: "welcome", Anonymous: "friend"}
+	Farewell = Interaction{Name: "farewell", Anonymous: "world"}
+)
+
+// DefaultRegistry holds every interaction the adapters serve.
+var DefaultRegistry = NewRegistry(Greeting, Cursing, Welcome, Farewell)
+
+// Reply validates name and says this interaction to it in locale.
+func (i Interaction) Reply(locale language.Tag, name string) (string, error) {
+	name, err := NormaliseName(name)
+	if err != nil {
+		return "", err
+	}
+	if name == "" && i.Anonymous != "" {
+		name = message(locale, i.Anonymous)
+	}
+	return fmt.Sprintf(message(locale, i.Name), name), nil
+}
+
+// Label is what adapters call this interaction in locale, e.g. "Greet".
+func (i Interaction) Label(locale language.Tag) string {
+	return message(locale, i.Name+".label")
+}
+
+// Registry is the set of interactions adapters build their routes, RPCs and
+// forms from, kept in the order they were registered.
+type Registry struct {
+	mu           sync.RWMutex
+	interactions []Interaction
+}
+
+func NewRegistry(interactions ...Interaction) *Registry {
+	r := &Registry{}
+	for _, i := range interactions {
+		if err := r.Register(i); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register adds i to the registry. Its Name must be unique and have a message
+// in the DefaultLocale catalogue.
+func (r *Registry) Register(i Interaction) error {
+	if message(DefaultLocale, i.Name) == "" {
+		return fmt.Errorf("interaction %q has no message in the %s catalogue", i.Name, DefaultLocale)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.interactions {
+		if existing.Name == i.Name {
+			return fmt.Errorf("interaction %q is already registered", i.Name)
+		}
+	}
+	r.interactions = append(r.interactions, i)
+	return nil
+}
+
+func (r *Registry) Lookup(name string) (Interaction, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, i := range r.interactions {
+		if i.Name == name {
+			return i, true
+		}
+	}
+	return Interaction{}, false
+}
+
+func (r *Registry) All() []Interaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Interact looks up the interaction called interaction and replies to name
+// with it, or fails with ErrUnknownInteraction.
+func (r *Registry) Interact(interaction string, locale language.Tag, name string) (string, error) {
+	i, ok := r.Lookup(interaction)
+	if !ok {
+		return "", ErrUnknownInteraction
+	}
+	return i.Reply(locale, name)
+}
@@ -0,0 +1,25 @@
+package interactions_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("names must be unique", func(t *testing.T) {
+		registry := interactions.NewRegistry(interactions.Greeting)
+		assert.Error(t, registry.Register(interactions.Greeting))
+	})
+
+	t.Run("interactions must have a message", func(t *testing.T) {
+		registry := interactions.NewRegistry()
+		assert.Error(t, registry.Register(interactions.Interaction{Name: "serenade"}))
+	})
+
+	t.Run("keeps registration order", func(t *testing.T) {
+		registry := interactions.NewRegistry(interactions.Farewell, interactions.Greeting)
+		assert.Equal(t, []interactions.Interaction{interactions.Farewell, interactions.Greeting}, registry.All())
+	})
+}
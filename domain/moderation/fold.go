@@ -0,0 +1,128 @@
+package moderation
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// lookalikes folds letters from other scripts, and the digits and symbols
+// people spell words with, onto the Latin letter they pass for.
+var lookalikes = map[rune]string{
+	// Cyrillic
+	'а': "a", 'в': "b", 'е': "e", 'ё': "e", 'к': "k", 'м': "m", 'н': "h", 'о': "o",
+	'р': "p", 'с': "c", 'т': "t", 'у': "y", 'х': "x", 'ѕ': "s", 'і': "i", 'ї': "i",
+	'ј': "j", 'ԁ': "d", 'һ': "h", 'ԛ': "q", 'ԝ': "w", 'ү': "y",
+	// Greek
+	'α': "a", 'β': "b", 'ε': "e", 'η': "n", 'ι': "i", 'κ': "k", 'ν': "v", 'ο': "o",
+	'ρ': "p", 'τ': "t", 'υ': "u", 'χ': "x", 'ω': "w",
+	// Digits and symbols
+	'0': "o", '1': "i", '3': "e", '4': "a", '5': "s", '7': "t", '8': "b",
+	'@': "a", '$': "s", '!': "i", '|': "l", '€': "e",
+}
+
+// segment is a run of a name that is either a word, to be checked, or the
+// punctuation and spaces between words.
+type segment struct {
+	text   string
+	folded string
+	word   bool
+}
+
+// split splits name into words and what's between them, folding each word
+// so it can be compared with the blocklist.
+func split(name string) []segment {
+	var (
+		segments []segment
+		current  segment
+		text     strings.Builder
+		folded   strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			current.text, current.folded = text.String(), folded.String()
+			segments = append(segments, current)
+		}
+		text.Reset()
+		folded.Reset()
+	}
+	for _, r := range name {
+		f := foldRune(r)
+		isWord := f != "" && strings.IndexFunc(f, isNotWordRune) == -1
+		if isWord != current.word {
+			flush()
+			current.word = isWord
+		}
+		text.WriteRune(r)
+		folded.WriteString(f)
+	}
+	flush()
+	return segments
+}
+
+// foldRune is r in lower case, without accents, and as the Latin letter it
+// looks like, if any.
+func foldRune(r rune) string {
+	var folded strings.Builder
+	for _, d := range norm.NFKD.String(string(r)) {
+		if unicode.Is(unicode.Mn, d) {
+			continue
+		}
+		d = unicode.ToLower(d)
+		if latin, ok := lookalikes[d]; ok {
+			folded.WriteString(latin)
+			continue
+		}
+		folded.WriteRune(d)
+	}
+	return folded.String()
+}
+
+func foldWord(word string) string {
+	var folded strings.Builder
+	for _, r := range word {
+		folded.WriteString(foldRune(r))
+	}
+	return folded.String()
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// squeeze collapses runs of the same letter, so "fuuuck" matches "fuck".
+func squeeze(s string) string {
+	var (
+		squeezed strings.Builder
+		last     rune = -1
+	)
+	for _, r := range s {
+		if r != last {
+			squeezed.WriteRune(r)
+		}
+		last = r
+	}
+	return squeezed.String()
+}
+
+// confusableScripts are the scripts whose letters pass for each other.
+var confusableScripts = []*unicode.RangeTable{unicode.Latin, unicode.Greek, unicode.Cyrillic}
+
+// mixesScripts reports whether word has letters from more than one of the
+// confusable scripts, e.g. a Cyrillic "і" among Latin letters.
+func mixesScripts(word string) bool {
+	var seen *unicode.RangeTable
+	for _, r := range word {
+		for _, script := range confusableScripts {
+			if !unicode.Is(script, r) {
+				continue
+			}
+			if seen != nil && seen != script {
+				return true
+			}
+			seen = script
+		}
+	}
+	return false
+}
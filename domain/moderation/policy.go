@@ -0,0 +1,95 @@
+// Package moderation decides whether a name may be said back to people, and
+// masks or refuses the words in it that may not.
+package moderation
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// Action is what a Policy does with a name it won't allow.
+type Action int
+
+const (
+	// Reject refuses the name with ErrNameNotAllowed or ErrNameMixesScripts.
+	Reject Action = iota
+	// Mask replaces every offending word with asterisks.
+	Mask
+)
+
+// DefaultBlocklist is blocked unless a binary is configured with its own.
+var DefaultBlocklist = []string{
+	"asshole", "bastard", "bitch", "bollocks", "cunt", "fuck",
+	"shit", "slut", "twat", "wanker", "whore",
+}
+
+// DefaultAllowlist holds real words and places that contain blocked words.
+var DefaultAllowlist = []string{"scunthorpe"}
+
+// Policy checks each word of a name. A word is blocked when it contains a
+// blocklisted word once lookalike letters, accents, case and repeated letters
+// are folded away, so "Ｓh1iiT" is as blocked as "shit", unless the whole word
+// is allowlisted.
+type Policy struct {
+	Action Action
+	// AllowMixedScripts lets a word mix Latin, Greek and Cyrillic letters,
+	// which is otherwise treated as an attempt to spoof another word.
+	AllowMixedScripts bool
+
+	blocked []string
+	allowed map[string]bool
+}
+
+func NewPolicy(action Action, blocklist []string, allowlist []string) *Policy {
+	p := &Policy{Action: action, allowed: map[string]bool{}}
+	for _, word := range blocklist {
+		if folded := foldWord(word); folded != "" {
+			p.blocked = append(p.blocked, folded)
+		}
+	}
+	for _, word := range allowlist {
+		p.allowed[foldWord(word)] = true
+	}
+	return p
+}
+
+// Moderate returns name with any offending words masked, or refuses it,
+// depending on the policy's Action. name should already be normalised with
+// interactions.NormaliseName.
+func (p *Policy) Moderate(name string) (string, error) {
+	var moderated strings.Builder
+	for _, seg := range split(name) {
+		if !seg.word {
+			moderated.WriteString(seg.text)
+			continue
+		}
+		err := p.check(seg)
+		switch {
+		case err == nil:
+			moderated.WriteString(seg.text)
+		case p.Action == Mask:
+			moderated.WriteString(strings.Repeat("*", utf8.RuneCountInString(seg.text)))
+		default:
+			return "", err
+		}
+	}
+	return moderated.String(), nil
+}
+
+func (p *Policy) check(word segment) error {
+	if !p.AllowMixedScripts && mixesScripts(word.text) {
+		return interactions.ErrNameMixesScripts
+	}
+	if p.allowed[word.folded] {
+		return nil
+	}
+	squeezed := squeeze(word.folded)
+	for _, blocked := range p.blocked {
+		if strings.Contains(word.folded, blocked) || strings.Contains(squeezed, squeeze(blocked)) {
+			return interactions.ErrNameNotAllowed
+		}
+	}
+	return nil
+}
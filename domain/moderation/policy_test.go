@@ -0,0 +1,75 @@
+package moderation_test
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/domain/moderation"
+)
+
+func TestPolicy(t *testing.T) {
+	reject := moderation.NewPolicy(moderation.Reject, moderation.DefaultBlocklist, moderation.DefaultAllowlist)
+	mask := moderation.NewPolicy(moderation.Mask, moderation.DefaultBlocklist, moderation.DefaultAllowlist)
+
+	t.Run("leaves clean names alone", func(t *testing.T) {
+		for _, name := range []string{"Mike", "Zoë O'Brien-Łukasz", "Михаил", "Αλέξανδρος", "李小龍", "Ashley", ""} {
+			got, err := reject.Moderate(name)
+			assert.NoError(t, err, name)
+			assert.Equal(t, name, got)
+		}
+	})
+
+	t.Run("refuses blocked words however they are disguised", func(t *testing.T) {
+		for _, name := range []string{"shit", "Mike Shithead", "SH1T", "sh!t", "$hit", "shiiiiit", "ｓｈｉｔ", "shît", "Mike_the_shit"} {
+			_, err := reject.Moderate(name)
+			assert.IsError(t, err, interactions.ErrNameNotAllowed, name)
+		}
+	})
+
+	t.Run("masks only the offending words", func(t *testing.T) {
+		got, err := mask.Moderate("Mike Shithead-Smith")
+		assert.NoError(t, err)
+		assert.Equal(t, "Mike ********-Smith", got)
+	})
+
+	t.Run("allows allowlisted words containing blocked ones", func(t *testing.T) {
+		got, err := reject.Moderate("Scunthorpe United")
+		assert.NoError(t, err)
+		assert.Equal(t, "Scunthorpe United", got)
+	})
+
+	t.Run("refuses words mixing lookalike scripts", func(t *testing.T) {
+		// The "і" is Cyrillic.
+		_, err := reject.Moderate("Mіke")
+		assert.IsError(t, err, interactions.ErrNameMixesScripts)
+
+		got, err := mask.Moderate("Mіke Smith")
+		assert.NoError(t, err)
+		assert.Equal(t, "**** Smith", got)
+	})
+
+	t.Run("still folds lookalikes when mixed scripts are allowed", func(t *testing.T) {
+		lenient := moderation.NewPolicy(moderation.Reject, moderation.DefaultBlocklist, nil)
+		lenient.AllowMixedScripts = true
+
+		got, err := lenient.Moderate("Mіke")
+		assert.NoError(t, err)
+		assert.Equal(t, "Mіke", got)
+
+		// The "ѕ" and "і" are Cyrillic.
+		_, err = lenient.Moderate("ѕhіt")
+		assert.IsError(t, err, interactions.ErrNameNotAllowed)
+	})
+
+	t.Run("uses the configured lists", func(t *testing.T) {
+		custom := moderation.NewPolicy(moderation.Reject, []string{"Voldemort"}, []string{"shitake"})
+
+		_, err := custom.Moderate("Lord V0ldemort")
+		assert.IsError(t, err, interactions.ErrNameNotAllowed)
+
+		got, err := custom.Moderate("Shitake")
+		assert.NoError(t, err)
+		assert.Equal(t, "Shitake", got)
+	})
+}
@@ -0,0 +1,93 @@
+// Package ratelimit throttles callers with a token bucket each.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter gives every key a bucket of Burst tokens that refills at Rate tokens
+// a second. Each call to Allow takes a token, and is refused when there are
+// none left.
+type Limiter struct {
+	rate  float64
+	burst float64
+	// Now is the limiter's clock, time.Now when nil.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	at     time.Time
+}
+
+func NewLimiter(rate float64, burst int) *Limiter {
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow takes a token from key's bucket. When there isn't one it returns false
+// and how long until there will be.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, at: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.at = now
+
+	if b.tokens < 1 {
+		if l.rate <= 0 {
+			return false, time.Duration(math.MaxInt64)
+		}
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	return min(l.burst, b.tokens+now.Sub(b.at).Seconds()*l.rate)
+}
+
+// sweep forgets buckets that have refilled, as a new bucket would be the same,
+// so keys that stop calling don't use memory forever. It runs at most once a
+// full refill period.
+func (l *Limiter) sweep(now time.Time) {
+	if l.rate <= 0 {
+		return
+	}
+	refillPeriod := time.Duration(l.burst / l.rate * float64(time.Second))
+	if now.Sub(l.lastSweep) < refillPeriod {
+		return
+	}
+	l.lastSweep = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *Limiter) now() time.Time {
+	if l.Now == nil {
+		return time.Now()
+	}
+	return l.Now()
+}
@@ -0,0 +1,68 @@
+package ratelimit_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/ratelimit"
+)
+
+func TestLimiter(t *testing.T) {
+	newLimiter := func(rate float64, burst int) (*ratelimit.Limiter, func(time.Duration)) {
+		now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		limiter := ratelimit.NewLimiter(rate, burst)
+		limiter.Now = func() time.Time { return now }
+		return limiter, func(d time.Duration) { now = now.Add(d) }
+	}
+
+	t.Run("allows a burst then refuses until a token is back", func(t *testing.T) {
+		limiter, wait := newLimiter(2, 3)
+		for i := range 3 {
+			ok, _ := limiter.Allow("127.0.0.1")
+			assert.True(t, ok, "call %d", i)
+		}
+
+		ok, retryAfter := limiter.Allow("127.0.0.1")
+		assert.False(t, ok)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+		wait(retryAfter)
+		ok, _ = limiter.Allow("127.0.0.1")
+		assert.True(t, ok)
+	})
+
+	t.Run("gives every key its own bucket", func(t *testing.T) {
+		limiter, _ := newLimiter(1, 1)
+		ok, _ := limiter.Allow("alice")
+		assert.True(t, ok)
+		ok, _ = limiter.Allow("alice")
+		assert.False(t, ok)
+		ok, _ = limiter.Allow("bob")
+		assert.True(t, ok)
+	})
+
+	t.Run("refills no further than the burst", func(t *testing.T) {
+		limiter, wait := newLimiter(1, 2)
+		wait(time.Hour)
+		for range 2 {
+			ok, _ := limiter.Allow("alice")
+			assert.True(t, ok)
+		}
+		ok, _ := limiter.Allow("alice")
+		assert.False(t, ok)
+	})
+
+	t.Run("forgets idle callers without giving them extra tokens", func(t *testing.T) {
+		limiter, wait := newLimiter(1, 1)
+		for i := range 100 {
+			_, _ = limiter.Allow(fmt.Sprint(i))
+		}
+		wait(time.Second)
+		ok, _ := limiter.Allow("0")
+		assert.True(t, ok)
+		ok, _ = limiter.Allow("0")
+		assert.False(t, ok)
+	})
+}
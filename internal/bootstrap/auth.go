@@ -0,0 +1,82 @@
+package bootstrap
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/quii/go-specs-greet/domain/auth"
+)
+
+const (
+	// minTokenSecretBytes is the shortest secret worth signing tokens with.
+	minTokenSecretBytes = 32
+	// sessionAudience is what web session tokens are for, so they can't be
+	// used as bearer tokens, nor bearer tokens as sessions.
+	sessionAudience = "webserver-session"
+)
+
+// AuthConfig is who a binary lets make authenticated interactions.
+type AuthConfig struct {
+	// APIKeys is a comma-separated list of user:key pairs.
+	APIKeys string
+	// TokenSecret verifies HMAC-signed bearer tokens. Without one, bearer
+	// tokens aren't accepted and web sessions are signed with a secret that
+	// only lasts as long as the process.
+	TokenSecret string
+}
+
+func (c *AuthConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.APIKeys, "api-keys", "API_KEYS", c.APIKeys, "comma-separated user:key pairs allowed to make authenticated interactions")
+	flags.StringVar(&c.TokenSecret, "token-secret", "TOKEN_SECRET", c.TokenSecret, fmt.Sprintf("secret of at least %d bytes that bearer tokens are signed with", minTokenSecretBytes))
+}
+
+func (c AuthConfig) Validate() error {
+	if _, err := c.apiKeys(); err != nil {
+		return err
+	}
+	if c.TokenSecret != "" && len(c.TokenSecret) < minTokenSecretBytes {
+		return fmt.Errorf("-token-secret must be at least %d bytes", minTokenSecretBytes)
+	}
+	return nil
+}
+
+// NewAuthenticator returns the authenticator c describes, and the tokens web
+// sessions are issued with, which it doesn't accept.
+func (c AuthConfig) NewAuthenticator() (auth.Authenticator, *auth.Tokens, error) {
+	keys, err := c.apiKeys()
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.TokenSecret != "" {
+		sessions := auth.NewTokens([]byte(c.TokenSecret))
+		sessions.Audience = sessionAudience
+		return auth.Any{keys, auth.NewTokens([]byte(c.TokenSecret))}, sessions, nil
+	}
+
+	secret := make([]byte, minTokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, nil, err
+	}
+	sessions := auth.NewTokens(secret)
+	sessions.Audience = sessionAudience
+	return auth.Any{keys}, sessions, nil
+}
+
+func (c AuthConfig) apiKeys() (auth.APIKeys, error) {
+	keys := auth.APIKeys{}
+	if c.APIKeys == "" {
+		return keys, nil
+	}
+	for _, pair := range strings.Split(c.APIKeys, ",") {
+		user, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || user == "" || key == "" {
+			return nil, fmt.Errorf("-api-keys: %q isn't a user:key pair", pair)
+		}
+		if _, taken := keys[key]; taken {
+			return nil, fmt.Errorf("-api-keys: %s's key is already someone else's", user)
+		}
+		keys[key] = user
+	}
+	return keys, nil
+}
@@ -0,0 +1,43 @@
+package bootstrap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
+)
+
+func TestAuthConfig(t *testing.T) {
+	secret := "a-token-secret-of-at-least-32-bytes"
+	cfg := bootstrap.AuthConfig{APIKeys: "alice:s3cret", TokenSecret: secret}
+	assert.NoError(t, cfg.Validate())
+	authenticator, sessions, err := cfg.NewAuthenticator()
+	assert.NoError(t, err)
+
+	t.Run("accepts API keys and bearer tokens", func(t *testing.T) {
+		user, err := authenticator.Authenticate(auth.Credentials{APIKey: "s3cret"})
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", user)
+
+		user, err = authenticator.Authenticate(auth.Credentials{BearerToken: auth.NewTokens([]byte(secret)).Issue("bob", time.Hour)})
+		assert.NoError(t, err)
+		assert.Equal(t, "bob", user)
+	})
+
+	t.Run("doesn't accept web sessions as bearer tokens", func(t *testing.T) {
+		_, err := authenticator.Authenticate(auth.Credentials{BearerToken: sessions.Issue("alice", time.Hour)})
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	t.Run("doesn't accept bearer tokens as web sessions", func(t *testing.T) {
+		_, err := sessions.Verify(auth.NewTokens([]byte(secret)).Issue("bob", time.Hour))
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	t.Run("refuses short token secrets", func(t *testing.T) {
+		assert.Error(t, bootstrap.AuthConfig{TokenSecret: "short"}.Validate())
+	})
+}
@@ -0,0 +1,73 @@
+package bootstrap
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Flags is a flag.FlagSet whose defaults can be overridden by environment
+// variables, so a binary can be configured either way with flags winning.
+type Flags struct {
+	*flag.FlagSet
+	getenv func(string) string
+	errs   []error
+}
+
+func NewFlags(name string, getenv func(string) string) *Flags {
+	return &Flags{
+		FlagSet: flag.NewFlagSet(name, flag.ContinueOnError),
+		getenv:  getenv,
+	}
+}
+
+func (f *Flags) StringVar(p *string, name string, env string, value string, usage string) {
+	if fromEnv := f.getenv(env); fromEnv != "" {
+		value = fromEnv
+	}
+	f.FlagSet.StringVar(p, name, value, fmt.Sprintf("%s ($%s)", usage, env))
+}
+
+func (f *Flags) DurationVar(p *time.Duration, name string, env string, value time.Duration, usage string) {
+	if fromEnv := f.getenv(env); fromEnv != "" {
+		d, err := time.ParseDuration(fromEnv)
+		if err != nil {
+			f.errs = append(f.errs, fmt.Errorf("$%s: %w", env, err))
+		}
+		value = d
+	}
+	f.FlagSet.DurationVar(p, name, value, fmt.Sprintf("%s ($%s)", usage, env))
+}
+
+func (f *Flags) IntVar(p *int, name string, env string, value int, usage string) {
+	if fromEnv := f.getenv(env); fromEnv != "" {
+		i, err := strconv.Atoi(fromEnv)
+		if err != nil {
+			f.errs = append(f.errs, fmt.Errorf("$%s: %w", env, err))
+		}
+		value = i
+	}
+	f.FlagSet.IntVar(p, name, value, fmt.Sprintf("%s ($%s)", usage, env))
+}
+
+func (f *Flags) BoolVar(p *bool, name string, env string, value bool, usage string) {
+	if fromEnv := f.getenv(env); fromEnv != "" {
+		b, err := strconv.ParseBool(fromEnv)
+		if err != nil {
+			f.errs = append(f.errs, fmt.Errorf("$%s: %w", env, err))
+		}
+		value = b
+	}
+	f.FlagSet.BoolVar(p, name, value, fmt.Sprintf("%s ($%s)", usage, env))
+}
+
+// Parse parses args, reporting any environment variables that couldn't be
+// parsed along with any problem with the flags themselves.
+func (f *Flags) Parse(args []string) error {
+	if err := errors.Join(f.errs...); err != nil {
+		return err
+	}
+	return f.FlagSet.Parse(args)
+}
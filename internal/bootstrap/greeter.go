@@ -0,0 +1,59 @@
+package bootstrap
+
+import (
+	"errors"
+	"time"
+
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/ratelimit"
+)
+
+// GreeterConfig is how a binary's greeter.Service behaves, whichever adapter
+// serves it.
+type GreeterConfig struct {
+	History    HistoryConfig
+	Moderation ModerationConfig
+	// ThrottleBurst is how many throttled interactions a caller may make in a
+	// row, and ThrottleInterval how long each one takes to earn back.
+	ThrottleBurst    int
+	ThrottleInterval time.Duration
+}
+
+func DefaultGreeterConfig() GreeterConfig {
+	return GreeterConfig{
+		Moderation:       DefaultModerationConfig(),
+		ThrottleBurst:    30,
+		ThrottleInterval: 2 * time.Second,
+	}
+}
+
+func (c *GreeterConfig) RegisterFlags(flags *Flags) {
+	c.History.RegisterFlags(flags)
+	c.Moderation.RegisterFlags(flags)
+	flags.IntVar(&c.ThrottleBurst, "throttle-burst", "THROTTLE_BURST", c.ThrottleBurst, "how many curses a caller may make in a row")
+	flags.DurationVar(&c.ThrottleInterval, "throttle-interval", "THROTTLE_INTERVAL", c.ThrottleInterval, "how long it takes a caller to earn back a curse")
+}
+
+func (c GreeterConfig) Validate() error {
+	if c.ThrottleBurst < 1 || c.ThrottleInterval <= 0 {
+		return errors.New("-throttle-burst and -throttle-interval must be positive")
+	}
+	return c.Moderation.Validate()
+}
+
+// NewService opens the configured history and returns a service using it,
+// along with a func that releases the history.
+func (c GreeterConfig) NewService() (*greeter.Service, func() error, error) {
+	policy, err := c.Moderation.NewPolicy()
+	if err != nil {
+		return nil, nil, err
+	}
+	repository, closeHistory, err := c.History.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	limiter := ratelimit.NewLimiter(float64(time.Second)/float64(c.ThrottleInterval), c.ThrottleBurst)
+	service := greeter.NewService(repository, limiter)
+	service.Moderation = policy
+	return service, closeHistory, nil
+}
@@ -0,0 +1,27 @@
+package bootstrap
+
+import (
+	"github.com/quii/go-specs-greet/adapters/filehistory"
+	"github.com/quii/go-specs-greet/domain/history"
+)
+
+// HistoryConfig is where a binary keeps the history of interactions.
+type HistoryConfig struct {
+	File string
+}
+
+func (c *HistoryConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.File, "history-file", "HISTORY_FILE", c.File, "file to keep interaction history in, or empty to keep it in memory")
+}
+
+// Open returns the repository c describes and a func that releases it.
+func (c HistoryConfig) Open() (history.Repository, func() error, error) {
+	if c.File == "" {
+		return history.NewInMemory(), func() error { return nil }, nil
+	}
+	store, err := filehistory.Open(c.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	return store, store.Close, nil
+}
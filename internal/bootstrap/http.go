@@ -0,0 +1,160 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/quii/go-specs-greet/internal/certs"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// HTTPConfig is how the HTTP binaries listen and how long they give clients.
+type HTTPConfig struct {
+	Port              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	// ShutdownTimeout is how long in-flight requests get to finish once the
+	// server has been asked to stop.
+	ShutdownTimeout time.Duration
+	// TLS serves HTTPS, which speaks HTTP/2 to clients that can, instead of
+	// HTTP.
+	TLS TLSConfig
+	// SelfSigned serves HTTPS with a certificate made up at startup, for
+	// development, when TLS has no certificate.
+	SelfSigned bool
+	// H2C serves HTTP/2 without TLS to clients that know to ask for it, e.g.
+	// a proxy that terminates TLS.
+	H2C bool
+}
+
+func DefaultHTTPConfig(port string) HTTPConfig {
+	return HTTPConfig{
+		Port:              port,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		ShutdownTimeout:   10 * time.Second,
+	}
+}
+
+// RegisterFlags adds flags (and their environment variables) for every field
+// of c, defaulting to its current values.
+func (c *HTTPConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.Port, "port", "PORT", c.Port, "port to listen on")
+	flags.DurationVar(&c.ReadHeaderTimeout, "read-header-timeout", "READ_HEADER_TIMEOUT", c.ReadHeaderTimeout, "how long a client has to send request headers")
+	flags.DurationVar(&c.ReadTimeout, "read-timeout", "READ_TIMEOUT", c.ReadTimeout, "how long a client has to send a whole request")
+	flags.DurationVar(&c.WriteTimeout, "write-timeout", "WRITE_TIMEOUT", c.WriteTimeout, "how long a response may take to write")
+	flags.DurationVar(&c.IdleTimeout, "idle-timeout", "IDLE_TIMEOUT", c.IdleTimeout, "how long to keep idle connections open")
+	flags.DurationVar(&c.ShutdownTimeout, "shutdown-timeout", "SHUTDOWN_TIMEOUT", c.ShutdownTimeout, "how long in-flight requests get to finish on shutdown")
+	c.TLS.RegisterFlags(flags)
+	flags.BoolVar(&c.SelfSigned, "tls-self-signed", "TLS_SELF_SIGNED", c.SelfSigned, "serve HTTPS with a self-signed certificate, for development")
+	flags.BoolVar(&c.H2C, "h2c", "H2C", c.H2C, "serve HTTP/2 without TLS to clients that ask for it")
+}
+
+// Validate reports settings that can't work together.
+func (c HTTPConfig) Validate() error {
+	if err := c.TLS.Validate(); err != nil {
+		return err
+	}
+	if c.SelfSigned && c.TLS.Enabled() {
+		return errors.New("-tls-self-signed and -tls-cert can't be used together")
+	}
+	if c.H2C && (c.SelfSigned || c.TLS.Enabled()) {
+		return errors.New("-h2c is for serving without TLS")
+	}
+	return nil
+}
+
+// selfSignedHosts are the names a self-signed certificate is good for.
+var selfSignedHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// tlsConfig returns the TLS configuration c serves with, or nil when it
+// serves plaintext.
+func (c HTTPConfig) tlsConfig() (*tls.Config, error) {
+	if !c.SelfSigned {
+		return c.TLS.ServerConfig()
+	}
+	ca, err := certs.NewCA("go-specs-greet development CA")
+	if err != nil {
+		return nil, err
+	}
+	pair, err := ca.Issue("localhost", selfSignedHosts...)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := pair.TLSCertificate()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("serving a self-signed certificate for %v, which clients won't trust", selfSignedHosts)
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// ListenAndServeHTTP listens on cfg.Port and then behaves like ServeHTTP.
+func ListenAndServeHTTP(ctx context.Context, cfg HTTPConfig, handler http.Handler) error {
+	lis, err := net.Listen("tcp", ":"+cfg.Port)
+	if err != nil {
+		return err
+	}
+	return ServeHTTP(ctx, lis, cfg, handler)
+}
+
+// ServeHTTP serves handler on lis until ctx is done, then stops accepting
+// connections and gives in-flight requests up to cfg.ShutdownTimeout to
+// finish before closing them.
+func ServeHTTP(ctx context.Context, lis net.Listener, cfg HTTPConfig, handler http.Handler) error {
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.H2C {
+		handler = h2c.NewHandler(handler, &http2.Server{IdleTimeout: cfg.IdleTimeout})
+	}
+	server := &http.Server{
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			// The certificate is already in TLSConfig, which ServeTLS adds
+			// HTTP/2 to.
+			served <- server.ServeTLS(lis, "", "")
+			return
+		}
+		served <- server.Serve(lis)
+	}()
+	log.Printf("listening on %s", lis.Addr())
+
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, waiting up to %s for in-flight requests", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		_ = server.Close()
+		return err
+	}
+	if err := <-served; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
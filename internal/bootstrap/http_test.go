@@ -0,0 +1,203 @@
+package bootstrap_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
+	"github.com/quii/go-specs-greet/internal/certs"
+	"golang.org/x/net/http2"
+)
+
+func TestServeHTTP(t *testing.T) {
+	t.Run("in-flight requests complete during shutdown", func(t *testing.T) {
+		var (
+			started = make(chan struct{})
+			release = make(chan struct{})
+			handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				close(started)
+				<-release
+				_, _ = io.WriteString(w, "finished")
+			})
+		)
+
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		ctx, shutdown := context.WithCancel(context.Background())
+		served := make(chan error, 1)
+		go func() {
+			served <- bootstrap.ServeHTTP(ctx, lis, bootstrap.DefaultHTTPConfig("0"), handler)
+		}()
+
+		type response struct {
+			body string
+			err  error
+		}
+		responses := make(chan response, 1)
+		go func() {
+			res, err := http.Get("http://" + lis.Addr().String())
+			if err != nil {
+				responses <- response{err: err}
+				return
+			}
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			responses <- response{body: string(body), err: err}
+		}()
+
+		<-started
+		shutdown()
+		assertStopsAccepting(t, lis.Addr().String())
+		close(release)
+
+		got := <-responses
+		assert.NoError(t, got.err)
+		assert.Equal(t, "finished", got.body)
+		assert.NoError(t, <-served)
+	})
+
+	protocol := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+
+	t.Run("serves HTTP/2 over TLS", func(t *testing.T) {
+		ca, err := certs.NewCA("test CA")
+		assert.NoError(t, err)
+		pair, err := ca.Issue("server", "127.0.0.1")
+		assert.NoError(t, err)
+		cfg := bootstrap.DefaultHTTPConfig("0")
+		cfg.TLS = bootstrap.TLSConfig{Cert: string(pair.CertPEM), Key: string(pair.KeyPEM)}
+		addr := serve(t, cfg, protocol)
+
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: ca.Pool()}, ForceAttemptHTTP2: true}
+		assert.Equal(t, "HTTP/2.0", get(t, &http.Client{Transport: transport}, "https://"+addr))
+	})
+
+	t.Run("serves a self-signed certificate", func(t *testing.T) {
+		cfg := bootstrap.DefaultHTTPConfig("0")
+		cfg.SelfSigned = true
+		addr := serve(t, cfg, protocol)
+
+		_, err := http.Get("https://" + addr)
+		var unknownAuthority x509.UnknownAuthorityError
+		assert.True(t, errors.As(err, &unknownAuthority), "got %v", err)
+
+		transport := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, ForceAttemptHTTP2: true}
+		assert.Equal(t, "HTTP/2.0", get(t, &http.Client{Transport: transport}, "https://"+addr))
+	})
+
+	t.Run("serves HTTP/2 without TLS to clients that ask for it", func(t *testing.T) {
+		cfg := bootstrap.DefaultHTTPConfig("0")
+		cfg.H2C = true
+		addr := serve(t, cfg, protocol)
+
+		h2c := &http2.Transport{
+			AllowHTTP: true,
+			DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return new(net.Dialer).DialContext(ctx, network, addr)
+			},
+		}
+		assert.Equal(t, "HTTP/2.0", get(t, &http.Client{Transport: h2c}, "http://"+addr))
+		assert.Equal(t, "HTTP/1.1", get(t, http.DefaultClient, "http://"+addr))
+	})
+}
+
+// serve serves handler with cfg until the test ends, returning its address.
+func serve(t *testing.T, cfg bootstrap.HTTPConfig, handler http.Handler) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	ctx, shutdown := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- bootstrap.ServeHTTP(ctx, lis, cfg, handler)
+	}()
+	t.Cleanup(func() {
+		shutdown()
+		assert.NoError(t, <-served)
+	})
+	return lis.Addr().String()
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	res, err := client.Get(url)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestHTTPConfig(t *testing.T) {
+	env := map[string]string{"PORT": "9000", "WRITE_TIMEOUT": "3s"}
+	parse := func(args []string, getenv func(string) string) (bootstrap.HTTPConfig, error) {
+		cfg := bootstrap.DefaultHTTPConfig("8080")
+		flags := bootstrap.NewFlags("test", getenv)
+		cfg.RegisterFlags(flags)
+		if err := flags.Parse(args); err != nil {
+			return cfg, err
+		}
+		return cfg, cfg.Validate()
+	}
+	getenv := func(key string) string { return env[key] }
+
+	t.Run("environment overrides defaults", func(t *testing.T) {
+		cfg, err := parse(nil, getenv)
+		assert.NoError(t, err)
+		assert.Equal(t, "9000", cfg.Port)
+		assert.Equal(t, 3*time.Second, cfg.WriteTimeout)
+		assert.Equal(t, bootstrap.DefaultHTTPConfig("").ReadTimeout, cfg.ReadTimeout)
+	})
+
+	t.Run("flags override the environment", func(t *testing.T) {
+		cfg, err := parse([]string{"-port", "9001"}, getenv)
+		assert.NoError(t, err)
+		assert.Equal(t, "9001", cfg.Port)
+	})
+
+	t.Run("rejects unparseable environment variables", func(t *testing.T) {
+		_, err := parse(nil, func(string) string { return "soon" })
+		assert.Error(t, err)
+	})
+
+	t.Run("needs both halves of a TLS key pair", func(t *testing.T) {
+		_, err := parse([]string{"-tls-cert", "cert.pem"}, getenv)
+		assert.Error(t, err)
+	})
+
+	t.Run("serves a self-signed certificate or a given one, not both", func(t *testing.T) {
+		_, err := parse([]string{"-tls-self-signed", "-tls-cert", "cert.pem", "-tls-key", "key.pem"}, getenv)
+		assert.Error(t, err)
+	})
+
+	t.Run("only serves h2c without TLS", func(t *testing.T) {
+		cfg, err := parse([]string{"-h2c"}, getenv)
+		assert.NoError(t, err)
+		assert.True(t, cfg.H2C)
+		_, err = parse([]string{"-h2c", "-tls-self-signed"}, getenv)
+		assert.Error(t, err)
+	})
+}
+
+func assertStopsAccepting(t *testing.T, addr string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		conn, err := net.Dial("tcp", addr)
+		if err != nil {
+			return
+		}
+		_ = conn.Close()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s still accepting connections after shutdown", addr)
+}
@@ -0,0 +1,37 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"google.golang.org/grpc"
+)
+
+// MetricsPath is where servers expose Prometheus metrics.
+const MetricsPath = "/metrics"
+
+// InstrumentHTTP traces, logs and measures requests to handler, and serves
+// metrics beside it on MetricsPath.
+func InstrumentHTTP(logger *slog.Logger, metrics *telemetry.Metrics, handler http.Handler) http.Handler {
+	mux := AdminHandler(metrics)
+	mux.Handle("/", telemetry.TraceHTTP(telemetry.LogHTTP(logger, telemetry.MeasureHTTP(metrics, handler))))
+	return mux
+}
+
+// AdminHandler serves metrics on MetricsPath, for binaries that don't speak
+// HTTP on their main port.
+func AdminHandler(metrics *telemetry.Metrics) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, metrics.Handler())
+	return mux
+}
+
+// InstrumentGRPC returns the server options that trace, log and measure RPCs.
+func InstrumentGRPC(logger *slog.Logger, metrics *telemetry.Metrics) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.StatsHandler(telemetry.TraceGRPC()),
+		grpc.ChainUnaryInterceptor(telemetry.LogUnary(logger), telemetry.MeasureUnary(metrics)),
+		grpc.ChainStreamInterceptor(telemetry.LogStream(logger), telemetry.MeasureStream(metrics)),
+	}
+}
@@ -0,0 +1,25 @@
+package bootstrap
+
+import (
+	"io"
+	"log/slog"
+
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+)
+
+// LogConfig is how a binary writes its access logs.
+type LogConfig struct {
+	Format string
+}
+
+func DefaultLogConfig() LogConfig {
+	return LogConfig{Format: "text"}
+}
+
+func (c *LogConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.Format, "log-format", "LOG_FORMAT", c.Format, `access log format, "text" or "json"`)
+}
+
+func (c LogConfig) NewLogger(w io.Writer) (*slog.Logger, error) {
+	return telemetry.NewLogger(w, c.Format)
+}
@@ -0,0 +1,96 @@
+package bootstrap
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/quii/go-specs-greet/domain/moderation"
+)
+
+// ModerationConfig is how a binary moderates the names it's given.
+type ModerationConfig struct {
+	// Mode is "reject", "mask" or "off".
+	Mode string
+	// BlocklistFile and AllowlistFile replace the default lists with the
+	// words in a file, one per line, ignoring blank lines and # comments.
+	BlocklistFile string
+	AllowlistFile string
+	// Homoglyphs is "reject" to treat words mixing lookalike alphabets as
+	// spoofs, or "allow".
+	Homoglyphs string
+}
+
+func DefaultModerationConfig() ModerationConfig {
+	return ModerationConfig{Mode: "reject", Homoglyphs: "reject"}
+}
+
+func (c *ModerationConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.Mode, "moderation", "MODERATION", c.Mode, `what to do with names containing blocked words, "reject", "mask" or "off"`)
+	flags.StringVar(&c.BlocklistFile, "blocklist-file", "BLOCKLIST_FILE", c.BlocklistFile, "file of words to block, one per line, instead of the defaults")
+	flags.StringVar(&c.AllowlistFile, "allowlist-file", "ALLOWLIST_FILE", c.AllowlistFile, "file of words to allow despite containing blocked ones, one per line, instead of the defaults")
+	flags.StringVar(&c.Homoglyphs, "homoglyphs", "HOMOGLYPHS", c.Homoglyphs, `what to do with words mixing lookalike Latin, Greek and Cyrillic letters, "reject" or "allow"`)
+}
+
+func (c ModerationConfig) Validate() error {
+	switch c.Mode {
+	case "reject", "mask", "off":
+	default:
+		return fmt.Errorf("moderation %q, want \"reject\", \"mask\" or \"off\"", c.Mode)
+	}
+	switch c.Homoglyphs {
+	case "reject", "allow":
+	default:
+		return fmt.Errorf("homoglyphs %q, want \"reject\" or \"allow\"", c.Homoglyphs)
+	}
+	return nil
+}
+
+// NewPolicy returns the policy c describes, or nil when moderation is off.
+func (c ModerationConfig) NewPolicy() (*moderation.Policy, error) {
+	if c.Mode == "off" {
+		return nil, nil
+	}
+	blocklist, err := readWords(c.BlocklistFile, moderation.DefaultBlocklist)
+	if err != nil {
+		return nil, err
+	}
+	allowlist, err := readWords(c.AllowlistFile, moderation.DefaultAllowlist)
+	if err != nil {
+		return nil, err
+	}
+
+	action := moderation.Reject
+	if c.Mode == "mask" {
+		action = moderation.Mask
+	}
+	policy := moderation.NewPolicy(action, blocklist, allowlist)
+	policy.AllowMixedScripts = c.Homoglyphs == "allow"
+	return policy, nil
+}
+
+// readWords reads the words in path, or returns defaults when there's no path.
+func readWords(path string, defaults []string) ([]string, error) {
+	if path == "" {
+		return defaults, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	words := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return words, nil
+}
@@ -0,0 +1,99 @@
+package bootstrap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig is the certificate a server presents, and the CA its clients'
+// certificates must be signed by when it asks for them. Each is either PEM,
+// e.g. from an environment variable, or the name of a file holding it.
+type TLSConfig struct {
+	// Cert and Key turn TLS on. The server is plaintext without them.
+	Cert string
+	Key  string
+	// ClientCA requires clients to present a certificate it signed, for
+	// mutual TLS.
+	ClientCA string
+}
+
+func (c *TLSConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.Cert, "tls-cert", "TLS_CERT", c.Cert, "certificate to serve TLS with, as PEM or a file")
+	flags.StringVar(&c.Key, "tls-key", "TLS_KEY", c.Key, "private key of -tls-cert, as PEM or a file")
+	flags.StringVar(&c.ClientCA, "tls-client-ca", "TLS_CLIENT_CA", c.ClientCA, "CA clients must present a certificate from, as PEM or a file")
+}
+
+func (c TLSConfig) Validate() error {
+	if (c.Cert == "") != (c.Key == "") {
+		return errors.New("-tls-cert and -tls-key must be set together")
+	}
+	if c.ClientCA != "" && c.Cert == "" {
+		return errors.New("-tls-client-ca needs -tls-cert and -tls-key")
+	}
+	return nil
+}
+
+func (c TLSConfig) Enabled() bool {
+	return c.Cert != ""
+}
+
+// ServerConfig returns the TLS configuration c describes, or nil when TLS is
+// off.
+func (c TLSConfig) ServerConfig() (*tls.Config, error) {
+	if !c.Enabled() {
+		return nil, nil
+	}
+	certPEM, err := readPEM(c.Cert)
+	if err != nil {
+		return nil, fmt.Errorf("-tls-cert: %w", err)
+	}
+	keyPEM, err := readPEM(c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("-tls-key: %w", err)
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}
+
+	if c.ClientCA != "" {
+		caPEM, err := readPEM(c.ClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("-tls-client-ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("-tls-client-ca has no certificates in it")
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
+
+// GRPCServerOptions returns the options that serve TLS as c describes, or
+// none when TLS is off.
+func (c TLSConfig) GRPCServerOptions() ([]grpc.ServerOption, error) {
+	config, err := c.ServerConfig()
+	if err != nil || config == nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(config))}, nil
+}
+
+// readPEM returns value if it is PEM, and otherwise the contents of the file
+// it names.
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN ") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
@@ -0,0 +1,54 @@
+package bootstrap_test
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
+	"github.com/quii/go-specs-greet/internal/certs"
+)
+
+func TestTLSConfig(t *testing.T) {
+	ca, err := certs.NewCA("test CA")
+	assert.NoError(t, err)
+	serverCert, err := ca.Issue("server", "localhost")
+	assert.NoError(t, err)
+
+	t.Run("is off without a certificate", func(t *testing.T) {
+		config, err := bootstrap.TLSConfig{}.ServerConfig()
+		assert.NoError(t, err)
+		assert.Zero(t, config)
+	})
+
+	t.Run("reads PEM from files", func(t *testing.T) {
+		dir := t.TempDir()
+		write := func(name string, contents []byte) string {
+			path := filepath.Join(dir, name)
+			assert.NoError(t, os.WriteFile(path, contents, 0o600))
+			return path
+		}
+		config, err := bootstrap.TLSConfig{
+			Cert:     write("server.crt", serverCert.CertPEM),
+			Key:      write("server.key", serverCert.KeyPEM),
+			ClientCA: write("ca.crt", ca.CertPEM),
+		}.ServerConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(config.Certificates))
+		assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+	})
+
+	t.Run("reads PEM given directly", func(t *testing.T) {
+		config, err := bootstrap.TLSConfig{Cert: string(serverCert.CertPEM), Key: string(serverCert.KeyPEM)}.ServerConfig()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(config.Certificates))
+		assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+	})
+
+	t.Run("needs a key with the certificate", func(t *testing.T) {
+		assert.Error(t, bootstrap.TLSConfig{Cert: string(serverCert.CertPEM)}.Validate())
+		assert.Error(t, bootstrap.TLSConfig{ClientCA: string(ca.CertPEM)}.Validate())
+	})
+}
@@ -0,0 +1,41 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/quii/go-specs-greet/adapters/telemetry"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TraceConfig is where a binary sends its spans.
+type TraceConfig struct {
+	Exporter string
+}
+
+func DefaultTraceConfig() TraceConfig {
+	return TraceConfig{Exporter: "none"}
+}
+
+func (c *TraceConfig) RegisterFlags(flags *Flags) {
+	flags.StringVar(&c.Exporter, "trace-exporter", "TRACE_EXPORTER", c.Exporter, `where to send spans, "none" or "stdout"`)
+}
+
+// Install starts tracing as service, writing spans to w when exporting to
+// stdout. The returned func flushes any spans not yet exported.
+func (c TraceConfig) Install(service string, w io.Writer) (func(context.Context) error, error) {
+	switch c.Exporter {
+	case "none":
+		return func(context.Context) error { return nil }, nil
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			return nil, err
+		}
+		return telemetry.InstallTracing(service, sdktrace.NewBatchSpanProcessor(exporter)), nil
+	default:
+		return nil, fmt.Errorf("trace exporter %q, want \"none\" or \"stdout\"", c.Exporter)
+	}
+}
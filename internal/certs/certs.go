@@ -0,0 +1,138 @@
+// Package certs issues X.509 certificates, for tests that need a throwaway
+// certificate authority and servers that make their own in development.
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+)
+
+// validity is how long issued certificates last, which is long enough for
+// any test run or development session.
+const validity = 24 * time.Hour
+
+// KeyPair is a PEM encoded certificate and its private key.
+type KeyPair struct {
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+func (k KeyPair) TLSCertificate() (tls.Certificate, error) {
+	return tls.X509KeyPair(k.CertPEM, k.KeyPEM)
+}
+
+// CA is a certificate authority that only exists in memory.
+type CA struct {
+	KeyPair
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func NewCA(commonName string) (*CA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+
+	pair, cert, err := sign(template, template, key, key)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{KeyPair: pair, cert: cert, key: key}, nil
+}
+
+// Issue returns a certificate for commonName, valid for hosts (DNS names or
+// IP addresses), that can be used by servers and clients alike.
+func (ca *CA) Issue(commonName string, hosts ...string) (KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	template, err := newTemplate(commonName)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	pair, _, err := sign(template, ca.cert, key, ca.key)
+	return pair, err
+}
+
+// Pool is a pool holding just the CA, for trusting what it issues.
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// ClientConfig trusts servers with certificates the CA issued, presenting
+// client's certificate to those that ask for one. client may be nil.
+func (ca *CA) ClientConfig(client *KeyPair) (*tls.Config, error) {
+	config := &tls.Config{RootCAs: ca.Pool(), MinVersion: tls.VersionTLS12}
+	if client != nil {
+		cert, err := client.TLSCertificate()
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func newTemplate(commonName string) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		// Allow for the clocks of whatever checks the certificate being a
+		// little behind.
+		NotBefore: now.Add(-time.Hour),
+		NotAfter:  now.Add(validity),
+	}, nil
+}
+
+func sign(template, parent *x509.Certificate, key, parentKey *ecdsa.PrivateKey) (KeyPair, *x509.Certificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return KeyPair{}, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return KeyPair{}, nil, err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return KeyPair{}, nil, err
+	}
+	return KeyPair{
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, cert, nil
+}
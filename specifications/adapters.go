@@ -1,13 +1,56 @@
 package specifications
 
-type CurseAdapter func(name string) string
+import "context"
 
-func (g CurseAdapter) Curse(name string) (string, error) {
-	return g(name), nil
+// FullGreeter both greets and curses, for specifications that compare what
+// the same caller may do with each.
+type FullGreeter interface {
+	Greeter
+	MeanGreeter
 }
 
-type GreetAdapter func(name string) string
+// CurseAdapter is a MeanGreeter that curses with a function. A driver
+// written before MeanGreeter took a context can be checked as
+// CurseAdapter(driver.Curse). It fails with ctx's error, without calling the
+// function, once ctx is done, but can't stop a call in progress.
+type CurseAdapter func(name string) (string, error)
 
-func (g GreetAdapter) Greet(name string) (string, error) {
-	return g(name), nil
+func (g CurseAdapter) Curse(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return g(name)
+}
+
+// GreetAdapter is a Greeter that greets with a function. A driver written
+// before Greeter took a context can be checked as GreetAdapter(driver.Greet).
+// It fails with ctx's error, without calling the function, once ctx is done,
+// but can't stop a call in progress.
+type GreetAdapter func(name string) (string, error)
+
+func (g GreetAdapter) Greet(ctx context.Context, name string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return g(name)
+}
+
+// InfallibleCurseAdapter is CurseAdapter for functions that can't fail, the
+// shape CurseAdapter had before cursing could fail.
+type InfallibleCurseAdapter func(name string) string
+
+func (g InfallibleCurseAdapter) Curse(ctx context.Context, name string) (string, error) {
+	return CurseAdapter(func(name string) (string, error) {
+		return g(name), nil
+	}).Curse(ctx, name)
+}
+
+// InfallibleGreetAdapter is GreetAdapter for functions that can't fail, the
+// shape GreetAdapter had before greeting could fail.
+type InfallibleGreetAdapter func(name string) string
+
+func (g InfallibleGreetAdapter) Greet(ctx context.Context, name string) (string, error) {
+	return GreetAdapter(func(name string) (string, error) {
+		return g(name), nil
+	}).Greet(ctx, name)
 }
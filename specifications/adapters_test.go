@@ -0,0 +1,73 @@
+package specifications_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+// legacyDriver is a driver written before Greeter and MeanGreeter took a
+// context.
+type legacyDriver struct{}
+
+func (legacyDriver) Greet(name string) (string, error) { return "Hello, " + name, nil }
+func (legacyDriver) Curse(name string) (string, error) { return "Go to hell, " + name + "!", nil }
+
+func TestAdapters(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	greeters := []struct {
+		name    string
+		greeter specifications.Greeter
+	}{
+		{"GreetAdapter", specifications.GreetAdapter(legacyDriver{}.Greet)},
+		{"InfallibleGreetAdapter", specifications.InfallibleGreetAdapter(func(name string) string {
+			return "Hello, " + name
+		})},
+	}
+	for _, g := range greeters {
+		t.Run(g.name+" greets", func(t *testing.T) {
+			got, err := g.greeter.Greet(context.Background(), "Mike")
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello, Mike", got)
+		})
+	}
+
+	meanies := []struct {
+		name  string
+		meany specifications.MeanGreeter
+	}{
+		{"CurseAdapter", specifications.CurseAdapter(legacyDriver{}.Curse)},
+		{"InfallibleCurseAdapter", specifications.InfallibleCurseAdapter(func(name string) string {
+			return "Go to hell, " + name + "!"
+		})},
+	}
+	for _, m := range meanies {
+		t.Run(m.name+" curses", func(t *testing.T) {
+			got, err := m.meany.Curse(context.Background(), "Chris")
+			assert.NoError(t, err)
+			assert.Equal(t, "Go to hell, Chris!", got)
+		})
+	}
+
+	t.Run("stop once the context is done", func(t *testing.T) {
+		called := false
+		greet := specifications.GreetAdapter(func(name string) (string, error) {
+			called = true
+			return "Hello, " + name, nil
+		})
+		curse := specifications.InfallibleCurseAdapter(func(name string) string {
+			called = true
+			return "Go to hell, " + name + "!"
+		})
+
+		_, err := greet.Greet(cancelled, "Mike")
+		assert.IsError(t, err, context.Canceled)
+		_, err = curse.Curse(cancelled, "Chris")
+		assert.IsError(t, err, context.Canceled)
+		assert.False(t, called, "called after the context was cancelled")
+	})
+}
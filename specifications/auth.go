@@ -0,0 +1,37 @@
+package specifications
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// CurseAuthenticationSpecification is for an adapter presenting no
+// credentials: it may greet but not curse.
+func CurseAuthenticationSpecification(t *testing.T, anonymous FullGreeter) {
+	step(t, "refuses anonymous curses", func(t *testing.T) {
+		_, err := anonymous.Curse(contextFor(t), "Chris")
+		assert.IsError(t, err, interactions.ErrUnauthenticated)
+	})
+
+	step(t, "greets anonymously", func(t *testing.T) {
+		got, err := anonymous.Greet(contextFor(t), "Mike")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, Mike", got)
+	})
+}
+
+// InvalidCredentialsSpecification is for an adapter presenting credentials
+// the system doesn't accept, which are refused whatever they're used for.
+func InvalidCredentialsSpecification(t *testing.T, impostor FullGreeter) {
+	step(t, "refuses curses", func(t *testing.T) {
+		_, err := impostor.Curse(contextFor(t), "Chris")
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+
+	step(t, "refuses greetings", func(t *testing.T) {
+		_, err := impostor.Greet(contextFor(t), "Mike")
+		assert.IsError(t, err, interactions.ErrInvalidCredentials)
+	})
+}
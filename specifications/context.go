@@ -0,0 +1,32 @@
+package specifications
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+// TracerName is the instrumentation scope of the span each specification step
+// runs in.
+const TracerName = "github.com/quii/go-specs-greet/specifications"
+
+// contextFor returns a context that is cancelled when the test finishes or
+// when its deadline (go test -timeout) passes, so a slow adapter fails the
+// specification rather than hanging it. It carries a span named after the
+// test, so every call the step makes through a driver joins one trace, and
+// the step's outcome is recorded for reports.
+func contextFor(t *testing.T) context.Context {
+	t.Helper()
+	steps.record(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if deadline, ok := t.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+		t.Cleanup(cancelDeadline)
+	}
+	ctx, span := otel.Tracer(TracerName).Start(ctx, t.Name())
+	t.Cleanup(func() { span.End() })
+	return ctx
+}
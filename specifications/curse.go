@@ -1,17 +1,15 @@
 package specifications
 
 import (
+	"context"
 	"testing"
-
-	"github.com/alecthomas/assert/v2"
 )
 
 type MeanGreeter interface {
-	Curse(name string) (string, error)
+	Curse(ctx context.Context, name string) (string, error)
 }
 
+// CurseSpecification checks meany against every scenario in CurseScenarios.
 func CurseSpecification(t *testing.T, meany MeanGreeter) {
-	got, err := meany.Curse("Chris")
-	assert.NoError(t, err)
-	assert.Equal(t, got, "Go to hell, Chris!")
+	runScenarios(t, meany, "Curse", CurseScenarios, meany.Curse)
 }
@@ -1,17 +1,15 @@
 package specifications
 
 import (
+	"context"
 	"testing"
-
-	"github.com/alecthomas/assert/v2"
 )
 
 type Greeter interface {
-	Greet(name string) (string, error)
+	Greet(ctx context.Context, name string) (string, error)
 }
 
+// GreetSpecification checks greeter against every scenario in GreetScenarios.
 func GreetSpecification(t *testing.T, greeter Greeter) {
-	got, err := greeter.Greet("Mike")
-	assert.NoError(t, err)
-	assert.Equal(t, got, "Hello, Mike")
+	runScenarios(t, greeter, "Greet", GreetScenarios, greeter.Greet)
 }
@@ -0,0 +1,64 @@
+package specifications
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+type Historian interface {
+	Greeter
+	MeanGreeter
+	History(ctx context.Context, query history.Query) ([]history.Entry, error)
+}
+
+// HistorySpecification greets and curses someone nobody has met before, so
+// it can pass against a server with history left over from earlier runs.
+func HistorySpecification(t *testing.T, historian Historian) {
+	name := fmt.Sprintf("Herodotus%d", time.Now().UnixNano())
+	before := time.Now().Add(-time.Minute)
+
+	ctx := contextFor(t)
+	_, err := historian.Greet(ctx, name)
+	assert.NoError(t, err)
+	_, err = historian.Curse(ctx, name)
+	assert.NoError(t, err)
+
+	step(t, "lists what was said to someone, most recent first", func(t *testing.T) {
+		got, err := historian.History(contextFor(t), history.Query{Name: name})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(got))
+		assert.Equal(t, interactions.Cursing.Name, got[0].Interaction)
+		assert.Equal(t, interactions.Greeting.Name, got[1].Interaction)
+		for _, entry := range got {
+			assert.Equal(t, name, entry.Name)
+			assert.NotEqual(t, "", entry.Adapter)
+			assert.True(t, entry.At.After(before), "%s is too long ago", entry.At)
+		}
+		assert.False(t, got[0].At.Before(got[1].At), "curse was recorded before the greeting")
+	})
+
+	step(t, "filters by interaction", func(t *testing.T) {
+		got, err := historian.History(contextFor(t), history.Query{Name: name, Interaction: interactions.Greeting.Name})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(got))
+		assert.Equal(t, interactions.Greeting.Name, got[0].Interaction)
+	})
+
+	step(t, "returns no more than asked for", func(t *testing.T) {
+		got, err := historian.History(contextFor(t), history.Query{Name: name, Limit: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(got))
+		assert.Equal(t, interactions.Cursing.Name, got[0].Interaction)
+	})
+
+	step(t, "refuses names that can't have been recorded", func(t *testing.T) {
+		_, err := historian.History(contextFor(t), history.Query{Name: "Mi\u0085ke"})
+		assert.IsError(t, err, interactions.ErrNameHasControlCharacters)
+	})
+}
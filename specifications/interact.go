@@ -0,0 +1,67 @@
+package specifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+type Interactor interface {
+	Interact(ctx context.Context, interaction string, locale string, name string) (string, error)
+}
+
+// interactionReplies are what each interaction says to Pepper in each
+// locale, written out so a change of wording is caught.
+var interactionReplies = map[string]map[string]string{
+	"greet": {
+		"en": "Hello, Pepper",
+		"fr": "Bonjour, Pepper",
+		"de": "Hallo, Pepper",
+		"es": "Hola, Pepper",
+	},
+	"curse": {
+		"en": "Go to hell, Pepper!",
+		"fr": "Va au diable, Pepper !",
+		"de": "Fahr zur Hölle, Pepper!",
+		"es": "¡Vete al infierno, Pepper!",
+	},
+	"welcome": {
+		"en": "Welcome, Pepper!",
+		"fr": "Bienvenue, Pepper !",
+		"de": "Willkommen, Pepper!",
+		"es": "¡Bienvenido, Pepper!",
+	},
+	"farewell": {
+		"en": "Goodbye, Pepper",
+		"fr": "Au revoir, Pepper",
+		"de": "Auf Wiedersehen, Pepper",
+		"es": "Adiós, Pepper",
+	},
+}
+
+// InteractionSpecification checks every interaction in the default registry,
+// in every locale, so a newly registered interaction or language fails it
+// until its wording is added here.
+func InteractionSpecification(t *testing.T, interactor Interactor) {
+	for _, interaction := range interactions.DefaultRegistry.All() {
+		for _, locale := range interactions.Locales() {
+			step(t, interaction.Name+"/"+locale.String(), func(t *testing.T) {
+				want, ok := interactionReplies[interaction.Name][locale.String()]
+				if !ok {
+					t.Fatalf("no reply to %s in %s is specified", interaction.Name, locale)
+				}
+
+				got, err := interactor.Interact(contextFor(t), interaction.Name, locale.String(), "Pepper")
+				assert.NoError(t, err)
+				assert.Equal(t, want, got)
+			})
+		}
+	}
+
+	step(t, "unknown interaction", func(t *testing.T) {
+		_, err := interactor.Interact(contextFor(t), "serenade", "", "Pepper")
+		assert.IsError(t, err, interactions.ErrUnknownInteraction)
+	})
+}
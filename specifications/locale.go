@@ -0,0 +1,50 @@
+package specifications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+type LocalisedGreeter interface {
+	GreetIn(ctx context.Context, locale string, name string) (string, error)
+}
+
+type LocalisedMeanGreeter interface {
+	CurseIn(ctx context.Context, locale string, name string) (string, error)
+}
+
+func LocalisedGreetSpecification(t *testing.T, greeter LocalisedGreeter) {
+	for locale, want := range map[string]string{
+		"en":           "Hello, Mike",
+		"fr":           "Bonjour, Mike",
+		"de-DE":        "Hallo, Mike",
+		"es-MX, en":    "Hola, Mike",
+		"ja":           "Hello, Mike",
+		"ja, fr;q=0.8": "Bonjour, Mike",
+	} {
+		step(t, locale, func(t *testing.T) {
+			got, err := greeter.GreetIn(contextFor(t), locale, "Mike")
+			assert.NoError(t, err)
+			assert.Equal(t, got, want)
+		})
+	}
+}
+
+func LocalisedCurseSpecification(t *testing.T, meany LocalisedMeanGreeter) {
+	for locale, want := range map[string]string{
+		"en":         "Go to hell, Chris!",
+		"fr-CA":      "Va au diable, Chris !",
+		"de":         "Fahr zur Hölle, Chris!",
+		"es, de":     "¡Vete al infierno, Chris!",
+		"tlh":        "Go to hell, Chris!",
+		"tlh, de-AT": "Fahr zur Hölle, Chris!",
+	} {
+		step(t, locale, func(t *testing.T) {
+			got, err := meany.CurseIn(contextFor(t), locale, "Chris")
+			assert.NoError(t, err)
+			assert.Equal(t, got, want)
+		})
+	}
+}
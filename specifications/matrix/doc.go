@@ -0,0 +1,8 @@
+// Package matrix checks every adapter against every specification in
+// specifications.DefaultMatrix, in one go test run:
+//
+//	ACCEPTANCE_SERVER=inprocess go test -v ./specifications/matrix
+//
+// Set SPECIFICATION_REPORTS to a directory to also get JUnit XML and living
+// documentation of the run there.
+package matrix
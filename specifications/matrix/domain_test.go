@@ -0,0 +1,84 @@
+package matrix_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/greeter"
+	"github.com/quii/go-specs-greet/domain/history"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/internal/bootstrap"
+)
+
+// domainDriver drives the domain directly, configured the way the binaries
+// are by default.
+type domainDriver struct {
+	service *greeter.Service
+	// user is who the driver calls as, or "" for anonymously.
+	user string
+}
+
+// startDomain starts drivers calling as user, throttled after burst curses,
+// as adapters.StartServer's servers are.
+func startDomain(user string, burst int) func(t *testing.T) *domainDriver {
+	return func(t *testing.T) *domainDriver {
+		config := bootstrap.DefaultGreeterConfig()
+		config.ThrottleBurst = burst
+		service, closeHistory, err := config.NewService()
+		assert.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, closeHistory()) })
+		return &domainDriver{service: service, user: user}
+	}
+}
+
+func (d *domainDriver) Greet(ctx context.Context, name string) (string, error) {
+	return d.GreetIn(ctx, "", name)
+}
+
+func (d *domainDriver) GreetIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Greeting.Name, locale, name)
+}
+
+func (d *domainDriver) Curse(ctx context.Context, name string) (string, error) {
+	return d.CurseIn(ctx, "", name)
+}
+
+func (d *domainDriver) CurseIn(ctx context.Context, locale string, name string) (string, error) {
+	return d.Interact(ctx, interactions.Cursing.Name, locale, name)
+}
+
+func (d *domainDriver) Interact(ctx context.Context, interaction string, locale string, name string) (string, error) {
+	i, ok := interactions.DefaultRegistry.Lookup(interaction)
+	if !ok {
+		return "", interactions.ErrUnknownInteraction
+	}
+	return d.service.Interact(ctx, greeter.Call{
+		Interaction: i,
+		Locale:      interactions.ParseLocale(locale),
+		Name:        name,
+		Caller:      "matrix",
+		User:        d.user,
+		Adapter:     "domain",
+	})
+}
+
+func (d *domainDriver) GreetMany(ctx context.Context, names []string) ([]string, error) {
+	return d.GreetManyIn(ctx, "", names)
+}
+
+func (d *domainDriver) GreetManyIn(ctx context.Context, locale string, names []string) ([]string, error) {
+	var greetings []string
+	for _, name := range names {
+		greeting, err := d.GreetIn(ctx, locale, name)
+		if err != nil {
+			return greetings, err
+		}
+		greetings = append(greetings, greeting)
+	}
+	return greetings, nil
+}
+
+func (d *domainDriver) History(ctx context.Context, query history.Query) ([]history.Entry, error) {
+	return d.service.ListHistory(ctx, query)
+}
@@ -0,0 +1,91 @@
+package matrix_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/adapters"
+	"github.com/quii/go-specs-greet/adapters/cli"
+	"github.com/quii/go-specs-greet/adapters/grpcserver"
+	"github.com/quii/go-specs-greet/adapters/httpserver"
+	"github.com/quii/go-specs-greet/adapters/webserver"
+	"github.com/quii/go-specs-greet/domain/auth"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+// impostorAPIKey is an API key no server accepts.
+const impostorAPIKey = "guess"
+
+var throttled = adapters.WithEnv(adapters.ThrottledEnv)
+
+func init() {
+	specifications.DefaultMatrix.RegisterAdapter(
+		specifications.NewAdapter("domain", startDomain(adapters.TestUser, adapters.TestThrottleBurst),
+			specifications.WithSetup("CurseThrottle", startDomain(adapters.TestUser, adapters.ThrottledBurst)),
+			specifications.WithSetup("CurseAuthentication", startDomain("", adapters.TestThrottleBurst)),
+		),
+		specifications.NewAdapter("httpserver", startHTTP(auth.Credentials{APIKey: adapters.TestAPIKey}),
+			specifications.WithSetup("CurseThrottle", startHTTP(auth.Credentials{APIKey: adapters.TestAPIKey}, throttled)),
+			specifications.WithSetup("CurseAuthentication", startHTTP(auth.Credentials{})),
+			specifications.WithSetup("InvalidCredentials", startHTTP(auth.Credentials{APIKey: impostorAPIKey})),
+		),
+		specifications.NewAdapter("grpcserver", startGRPC(auth.Credentials{BearerToken: adapters.TestToken()}),
+			specifications.WithSetup("CurseThrottle", startGRPC(auth.Credentials{BearerToken: adapters.TestToken()}, throttled)),
+			specifications.WithSetup("CurseAuthentication", startGRPC(auth.Credentials{})),
+			specifications.WithSetup("InvalidCredentials", startGRPC(auth.Credentials{APIKey: impostorAPIKey})),
+		),
+		specifications.NewAdapter("webserver", startWeb(adapters.TestAPIKey),
+			specifications.WithSetup("CurseThrottle", startWeb(adapters.TestAPIKey, throttled)),
+			specifications.WithSetup("CurseAuthentication", startWeb("")),
+			specifications.WithSetup("InvalidCredentials", startWeb(impostorAPIKey)),
+		),
+		specifications.NewAdapter("cli", func(t *testing.T) cli.Driver {
+			return cli.Driver{Binary: adapters.BuildBinary(t, "cli")}
+		}),
+	)
+}
+
+func TestMatrix(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	specifications.DefaultMatrix.Run(t)
+}
+
+// startHTTP starts drivers presenting credentials to a server of their own.
+func startHTTP(credentials auth.Credentials, opts ...adapters.ServerOption) func(t *testing.T) httpserver.Driver {
+	return func(t *testing.T) httpserver.Driver {
+		server := adapters.StartServer(t, "8080", "httpserver", opts...)
+		return httpserver.Driver{
+			BaseURL:     server.URL(),
+			Client:      server.HTTPClient(1 * time.Second),
+			Credentials: credentials,
+		}
+	}
+}
+
+// startGRPC starts drivers presenting credentials to a server of their own.
+func startGRPC(credentials auth.Credentials, opts ...adapters.ServerOption) func(t *testing.T) *grpcserver.Driver {
+	return func(t *testing.T) *grpcserver.Driver {
+		server := adapters.StartServer(t, "50051", "grpcserver", opts...)
+		driver := &grpcserver.Driver{Addr: server.Addr, TLS: server.ClientTLS(), Credentials: credentials}
+		t.Cleanup(driver.Close)
+		return driver
+	}
+}
+
+// startWeb starts drivers logging in with apiKey, or not at all when it's
+// empty, to a server of their own.
+func startWeb(apiKey string, opts ...adapters.ServerOption) func(t *testing.T) *webserver.Driver {
+	return func(t *testing.T) *webserver.Driver {
+		server := adapters.StartServer(t, "8081", "webserver", opts...)
+		driverOpts := []webserver.DriverOption{webserver.WithRootCAs(server.RootCAs)}
+		if apiKey != "" {
+			driverOpts = append(driverOpts, webserver.WithAPIKey(apiKey))
+		}
+		driver, cleanup := webserver.NewDriver(server.URL(), driverOpts...)
+		t.Cleanup(func() { assert.NoError(t, cleanup()) })
+		return driver
+	}
+}
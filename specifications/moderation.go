@@ -0,0 +1,41 @@
+package specifications
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// ModerationSpecification is what the default moderation policy, which
+// rejects rather than masks, looks like through an adapter.
+func ModerationSpecification(t *testing.T, greeter Greeter) {
+	refused := map[string]struct {
+		name string
+		want error
+	}{
+		"blocked word":                     {name: "Shithead", want: interactions.ErrNameNotAllowed},
+		"blocked word spelled with digits": {name: "Sh1thead", want: interactions.ErrNameNotAllowed},
+		"blocked word in full-width":       {name: "Ｓｈｉｔ", want: interactions.ErrNameNotAllowed},
+		// The "і" is Cyrillic.
+		"lookalike letters from another alphabet": {name: "Mіke", want: interactions.ErrNameMixesScripts},
+	}
+	for description, tc := range refused {
+		step(t, "refuses "+description, func(t *testing.T) {
+			_, err := greeter.Greet(contextFor(t), tc.name)
+			assert.IsError(t, err, tc.want)
+		})
+	}
+
+	allowed := map[string]string{
+		"allowlisted word containing a blocked one": "Scunthorpe",
+		"name in a single other alphabet":           "Михаил",
+	}
+	for description, name := range allowed {
+		step(t, "allows "+description, func(t *testing.T) {
+			got, err := greeter.Greet(contextFor(t), name)
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello, "+name, got)
+		})
+	}
+}
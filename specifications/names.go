@@ -0,0 +1,41 @@
+package specifications
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+// awkwardNames are valid names that are easy for an adapter to mangle on the
+// way through, by encoding, escaping or reordering them. They're in a slice,
+// not a map, so they're checked, and reported, in the same order every time.
+var awkwardNames = []struct {
+	description string
+	name        string
+}{
+	{"emoji", "Mike 🎉"},
+	{"emoji joined into one", "👩‍👩‍👧"},
+	{"accents", "Zoë Ångström"},
+	{"right-to-left script", "مايك"},
+	{"mixed directions", "Mike שלום"},
+	{"ampersand", "Tom & Jerry"},
+	{"hash", "#1 fan"},
+	{"query characters", "who? me=yes"},
+	{"percent", "100% Mike"},
+	{"plus", "Mike+Chris"},
+	{"slashes", "AC/DC \\o/"},
+	{"markup", "<b>Mike</b>"},
+	{"quotes", `Mike "The Mic" O'Brien`},
+}
+
+// AwkwardNameSpecification checks names with characters that mean something
+// to URLs, HTML or text layout come back exactly as they were sent.
+func AwkwardNameSpecification(t *testing.T, greeter Greeter) {
+	for _, awkward := range awkwardNames {
+		step(t, awkward.description, func(t *testing.T) {
+			got, err := greeter.Greet(contextFor(t), awkward.name)
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello, "+awkward.name, got)
+		})
+	}
+}
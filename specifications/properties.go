@@ -0,0 +1,137 @@
+package specifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// GeneratedName is a name testing/quick makes up from alphabets, emoji,
+// whitespace and punctuation that adapters might trip over. Each name's
+// letters come from one alphabet, as a real name's would, and there are no
+// more of them than testing/quick's size, which is well within
+// interactions.MaxNameLength.
+type GeneratedName string
+
+var (
+	alphabets = [][]rune{
+		[]rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"),
+		[]rune("àáâãäåæçèéêëìíîïñòóôõöøùúûüýÿßÀÉÎÕÜ"),
+		[]rune("αβγδεζηθικλμνξοπρστυφχψωΑΒΓΔΛΣΩ"),
+		[]rune("абвгдежзийклмнопрстуфхцчшщыэюяБДЖЯ"),
+		[]rune("ابتثجحخدذرزسشصضطظعغفقكلمنهوي"),
+		[]rune("אבגדהוזחטיכלמנסעפצקרשת"),
+		[]rune("日本語中文漢字ひらがなカタカナ한국어"),
+	}
+	extras = []rune(" 0123456789&#?%+=/\\'\"<>.,-_!@()🎉😀👍🏽❤️")
+)
+
+// Generate implements quick.Generator.
+func (GeneratedName) Generate(r *rand.Rand, size int) reflect.Value {
+	alphabet := alphabets[r.Intn(len(alphabets))]
+	runes := make([]rune, r.Intn(size+1))
+	for i := range runes {
+		if r.Intn(4) == 0 {
+			runes[i] = extras[r.Intn(len(extras))]
+		} else {
+			runes[i] = alphabet[r.Intn(len(alphabet))]
+		}
+	}
+	return reflect.ValueOf(GeneratedName(runes))
+}
+
+// BlankName is a made-up name that is only whitespace, or nothing at all.
+type BlankName string
+
+// Generate implements quick.Generator.
+func (BlankName) Generate(r *rand.Rand, size int) reflect.Value {
+	whitespace := []rune("   　")
+	runes := make([]rune, r.Intn(size+1))
+	for i := range runes {
+		runes[i] = whitespace[r.Intn(len(whitespace))]
+	}
+	return reflect.ValueOf(BlankName(runes))
+}
+
+// propertyChecks is how many names each property is checked with.
+const propertyChecks = 25
+
+// GreetPropertySpecification checks properties that hold for any name, with
+// names testing/quick makes up.
+func GreetPropertySpecification(t *testing.T, greeter Greeter) {
+	step(t, "greets with the trimmed name, the same way every time", func(t *testing.T) {
+		checkProperty(t, propertyChecks, func(name GeneratedName) bool {
+			return holdsFor(t, adapterName(greeter)+".Greet", greeter.Greet, string(name))
+		})
+	})
+
+	step(t, "greets the world when there's no name", func(t *testing.T) {
+		checkProperty(t, propertyChecks, func(name BlankName) bool {
+			got, err := greeter.Greet(contextFor(t), string(name))
+			assert.NoError(t, err, "%s.Greet(%q)", adapterName(greeter), name)
+			return got == "Hello, World"
+		})
+	})
+}
+
+// CursePropertySpecification checks properties that hold for any name, with
+// names testing/quick makes up.
+func CursePropertySpecification(t *testing.T, meany MeanGreeter) {
+	step(t, "curses with the trimmed name, the same way every time", func(t *testing.T) {
+		checkProperty(t, propertyChecks, func(name GeneratedName) bool {
+			return holdsFor(t, adapterName(meany)+".Curse", meany.Curse, string(name))
+		})
+	})
+}
+
+func checkProperty(t *testing.T, count int, property any) {
+	t.Helper()
+	if err := quick.Check(property, &quick.Config{MaxCount: count}); err != nil {
+		t.Error(err)
+	}
+}
+
+// holdsFor checks interact replies to name with the name as the domain
+// normalises it, or refuses it with the same domain error the domain would,
+// and that it does the same again when asked twice.
+func holdsFor(t *testing.T, call string, interact func(ctx context.Context, name string) (string, error), name string) bool {
+	t.Helper()
+	first, firstErr := interact(contextFor(t), name)
+	second, secondErr := interact(contextFor(t), name)
+	if first != second || fmt.Sprint(firstErr) != fmt.Sprint(secondErr) {
+		t.Logf("%s(%q) isn't deterministic: %q, %v then %q, %v", call, name, first, firstErr, second, secondErr)
+		return false
+	}
+
+	want, err := interactions.NormaliseName(name)
+	if err != nil {
+		if !errors.Is(firstErr, err) {
+			t.Logf("%s(%q) = %v, want %v", call, name, firstErr, err)
+			return false
+		}
+		return true
+	}
+	if firstErr != nil {
+		// Moderation may refuse a name the domain would otherwise accept,
+		// but nothing else should go wrong.
+		var domainErr *interactions.Error
+		if !errors.As(firstErr, &domainErr) || domainErr.Kind != interactions.KindRefused {
+			t.Logf("%s(%q) failed: %v", call, name, firstErr)
+			return false
+		}
+		return true
+	}
+	if !strings.Contains(first, want) {
+		t.Logf("%s(%q) = %q, which doesn't contain %q", call, name, first, want)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,276 @@
+package specifications
+
+import (
+	"embed"
+	"encoding/xml"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ReportDirEnv, when set, is the directory Matrix.Run writes its reports to:
+// JUnit XML for CI, and living documentation as Markdown and HTML.
+const ReportDirEnv = "SPECIFICATION_REPORTS"
+
+// The files WriteReports writes.
+const (
+	JUnitReport    = "junit.xml"
+	MarkdownReport = "specifications.md"
+	HTMLReport     = "specifications.html"
+)
+
+var (
+	//go:embed "report/*"
+	reportTemplates embed.FS
+
+	markdownTemplate = template.Must(template.New("specifications.md.tmpl").Funcs(template.FuncMap{
+		"cell": markdownCell,
+	}).ParseFS(reportTemplates, "report/specifications.md.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("specifications.html.tmpl").
+			ParseFS(reportTemplates, "report/specifications.html.tmpl"))
+)
+
+// WriteReports writes every report on results to dir, creating it if need be.
+func WriteReports(dir string, results []Result) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for name, write := range map[string]func(io.Writer, []Result) error{
+		JUnitReport:    WriteJUnit,
+		MarkdownReport: WriteMarkdown,
+		HTMLReport:     WriteHTML,
+	} {
+		if err := writeFile(filepath.Join(dir, name), results, write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(path string, results []Result, write func(io.Writer, []Result) error) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return write(f, results)
+}
+
+type junitSuites struct {
+	XMLName xml.Name     `xml:"testsuites"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnit writes results as JUnit XML, with a suite for each adapter and
+// a case for each step of each specification. Cells that were never run are
+// left out.
+func WriteJUnit(w io.Writer, results []Result) error {
+	var (
+		suites  junitSuites
+		byName  = map[string]int{}
+		elapsed = map[string]time.Duration{}
+	)
+	for _, result := range results {
+		if result.Outcome == NotRun {
+			continue
+		}
+		i, ok := byName[result.Adapter]
+		if !ok {
+			i = len(suites.Suites)
+			byName[result.Adapter] = i
+			suites.Suites = append(suites.Suites, junitSuite{Name: result.Adapter})
+		}
+		suite := &suites.Suites[i]
+		elapsed[result.Adapter] += result.Duration
+		for _, c := range junitCases(result) {
+			suite.Tests++
+			if c.Failure != nil {
+				suite.Failures++
+			}
+			if c.Skipped != nil {
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+	}
+	for i := range suites.Suites {
+		suites.Suites[i].Time = seconds(elapsed[suites.Suites[i].Name])
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitCases are a case for each of result's steps, plus one for the cell
+// itself when it has no steps or failed in a way none of them show.
+func junitCases(result Result) []junitCase {
+	classname := result.Adapter + "." + result.Specification
+	var (
+		cases       []junitCase
+		stepsFailed bool
+	)
+	for _, step := range result.Steps {
+		cases = append(cases, junitCaseFor(classname, step.Name, step.Outcome, step.Duration))
+		stepsFailed = stepsFailed || step.Outcome == Failed
+	}
+	if len(cases) == 0 || (result.Outcome == Failed && !stepsFailed) {
+		cases = append(cases, junitCaseFor(classname, result.Specification, result.Outcome, result.Duration))
+	}
+	return cases
+}
+
+func junitCaseFor(classname, name string, outcome Outcome, duration time.Duration) junitCase {
+	c := junitCase{Name: name, Classname: classname, Time: seconds(duration)}
+	switch outcome {
+	case Failed:
+		c.Failure = &junitMessage{Message: "failed, see the test log"}
+	case Skipped:
+		c.Skipped = &junitMessage{Message: "skipped"}
+	case NotApplicable:
+		c.Skipped = &junitMessage{Message: "the adapter can't be driven the way the specification needs"}
+	}
+	return c
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// documentation is the living documentation's view of a run.
+type documentation struct {
+	Adapters       []string
+	Specifications []documentedSpecification
+}
+
+type documentedSpecification struct {
+	Name        string
+	Description string
+	Anchor      string
+	Outcomes    []Outcome
+	Steps       []documentedStep
+}
+
+type documentedStep struct {
+	Name     string
+	Outcomes []Outcome
+}
+
+// Symbol is how the living documentation shows an outcome.
+func (o Outcome) Symbol() string {
+	switch o {
+	case Passed:
+		return "✅"
+	case Failed:
+		return "❌"
+	case Skipped:
+		return "⏭️"
+	case NotApplicable:
+		return "➖"
+	default:
+		return "❔"
+	}
+}
+
+func newDocumentation(results []Result) documentation {
+	var doc documentation
+	var specifications []string
+	for _, result := range results {
+		doc.Adapters = appendNew(doc.Adapters, result.Adapter)
+		specifications = appendNew(specifications, result.Specification)
+	}
+	column := map[string]int{}
+	for i, adapter := range doc.Adapters {
+		column[adapter] = i
+	}
+
+	for _, name := range specifications {
+		spec := documentedSpecification{
+			Name:     name,
+			Anchor:   strings.ToLower(name),
+			Outcomes: make([]Outcome, len(doc.Adapters)),
+		}
+		row := map[string]int{}
+		for _, result := range results {
+			if result.Specification != name {
+				continue
+			}
+			spec.Description = result.Description
+			spec.Outcomes[column[result.Adapter]] = result.Outcome
+			for _, step := range result.Steps {
+				i, ok := row[step.Name]
+				if !ok {
+					i = len(spec.Steps)
+					row[step.Name] = i
+					spec.Steps = append(spec.Steps, documentedStep{Name: step.Name, Outcomes: make([]Outcome, len(doc.Adapters))})
+				}
+				spec.Steps[i].Outcomes[column[result.Adapter]] = step.Outcome
+			}
+		}
+		// Adapters that can't run a specification can't run any of its steps.
+		for i, outcome := range spec.Outcomes {
+			if outcome != NotApplicable {
+				continue
+			}
+			for _, step := range spec.Steps {
+				step.Outcomes[i] = NotApplicable
+			}
+		}
+		doc.Specifications = append(doc.Specifications, spec)
+	}
+	return doc
+}
+
+// WriteMarkdown writes results as living documentation in Markdown: each
+// specification in plain English, and which adapters verify it and each of
+// its steps.
+func WriteMarkdown(w io.Writer, results []Result) error {
+	return markdownTemplate.Execute(w, newDocumentation(results))
+}
+
+// WriteHTML is WriteMarkdown as a web page.
+func WriteHTML(w io.Writer, results []Result) error {
+	return htmlTemplate.Execute(w, newDocumentation(results))
+}
+
+// markdownCell escapes s for a Markdown table cell.
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
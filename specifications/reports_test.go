@@ -0,0 +1,85 @@
+package specifications_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+var results = []specifications.Result{
+	{
+		Specification: "Greet",
+		Description:   "Greets people by name.",
+		Adapter:       "domain",
+		Outcome:       specifications.Passed,
+		Duration:      time.Millisecond,
+		Steps: []specifications.Step{
+			{Name: "empty name", Outcome: specifications.Passed},
+			{Name: "accents", Outcome: specifications.Passed},
+		},
+	},
+	{
+		Specification: "Greet",
+		Description:   "Greets people by name.",
+		Adapter:       "httpserver",
+		Outcome:       specifications.Failed,
+		Steps: []specifications.Step{
+			{Name: "empty name", Outcome: specifications.Passed},
+			{Name: "accents", Outcome: specifications.Failed},
+		},
+	},
+	{Specification: "Conversation", Adapter: "domain", Outcome: specifications.NotApplicable},
+	{Specification: "Conversation", Adapter: "httpserver", Outcome: specifications.NotRun},
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var out strings.Builder
+	assert.NoError(t, specifications.WriteJUnit(&out, results))
+
+	var report struct {
+		Suites []struct {
+			Name     string `xml:"name,attr"`
+			Tests    int    `xml:"tests,attr"`
+			Failures int    `xml:"failures,attr"`
+			Skipped  int    `xml:"skipped,attr"`
+			Cases    []struct {
+				Name      string `xml:"name,attr"`
+				Classname string `xml:"classname,attr"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	assert.NoError(t, xml.Unmarshal([]byte(out.String()), &report))
+
+	assert.Equal(t, 2, len(report.Suites))
+	domain, http := report.Suites[0], report.Suites[1]
+	assert.Equal(t, "domain", domain.Name)
+	assert.Equal(t, 3, domain.Tests)
+	assert.Equal(t, 1, domain.Skipped)
+	assert.Equal(t, "empty name", domain.Cases[0].Name)
+	assert.Equal(t, "domain.Greet", domain.Cases[0].Classname)
+	assert.Equal(t, "httpserver", http.Name)
+	assert.Equal(t, 2, http.Tests)
+	assert.Equal(t, 1, http.Failures)
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var out strings.Builder
+	assert.NoError(t, specifications.WriteMarkdown(&out, results))
+
+	assert.Contains(t, out.String(), "| [Greet](#greet) | ✅ | ❌ |")
+	assert.Contains(t, out.String(), "| [Conversation](#conversation) | ➖ | ❔ |")
+	assert.Contains(t, out.String(), "## Greet\n\nGreets people by name.")
+	assert.Contains(t, out.String(), "| accents | ✅ | ❌ |")
+}
+
+func TestWriteHTML(t *testing.T) {
+	var out strings.Builder
+	assert.NoError(t, specifications.WriteHTML(&out, results))
+
+	assert.Contains(t, out.String(), `<a href="#greet">Greet</a>`)
+	assert.Contains(t, out.String(), `<td>empty name</td>`)
+}
@@ -0,0 +1,395 @@
+package specifications
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"text/tabwriter"
+	"time"
+)
+
+// Specification is a specification a Matrix checks against every adapter
+// whose driver can be driven the way it needs.
+type Specification struct {
+	Name string
+	// Description is what the specification checks, in plain English, for
+	// the living documentation.
+	Description string
+	needs       reflect.Type
+	needsSetup  bool
+	check       func(t *testing.T, driver any)
+}
+
+// SpecificationOption configures a Specification.
+type SpecificationOption func(*Specification)
+
+// NeedsSetup checks the specification only against adapters with a setup
+// for it, because it needs drivers that are set up differently from usual,
+// such as with other credentials.
+func NeedsSetup() SpecificationOption {
+	return func(s *Specification) {
+		s.needsSetup = true
+	}
+}
+
+// NewSpecification is check, named name and described by description, for
+// drivers that implement D.
+func NewSpecification[D any](name string, description string, check func(t *testing.T, driver D), opts ...SpecificationOption) Specification {
+	s := Specification{
+		Name:        name,
+		Description: description,
+		needs:       reflect.TypeFor[D](),
+		check: func(t *testing.T, driver any) {
+			check(t, driver.(D))
+		},
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// AppliesTo reports whether adapter's driver for s implements what s needs.
+func (s Specification) AppliesTo(adapter Adapter) bool {
+	setup, ok := adapter.setupFor(s)
+	return ok && setup.driver.AssignableTo(s.needs)
+}
+
+// Adapter is how a Matrix gets a driver for something to check.
+type Adapter struct {
+	Name string
+	setup
+	// setups are by the name of the specification they're for.
+	setups map[string]setup
+}
+
+// setup is how an adapter starts drivers.
+type setup struct {
+	driver reflect.Type
+	start  func(t *testing.T) any
+}
+
+func newSetup[D any](start func(t *testing.T) D) setup {
+	return setup{
+		driver: reflect.TypeFor[D](),
+		start: func(t *testing.T) any {
+			return start(t)
+		},
+	}
+}
+
+// AdapterOption configures an Adapter.
+type AdapterOption func(*Adapter)
+
+// WithSetup has the adapter start its drivers for the specification named
+// specification with start, instead of the usual way, e.g. to present other
+// credentials or to talk to a server configured for the specification.
+func WithSetup[D any](specification string, start func(t *testing.T) D) AdapterOption {
+	return func(a *Adapter) {
+		a.setups[specification] = newSetup(start)
+	}
+}
+
+// NewAdapter is an adapter, named name, whose drivers start makes. start is
+// called for every specification the driver is checked against, so each
+// starts from a clean slate, and should clean up after itself with
+// t.Cleanup.
+func NewAdapter[D any](name string, start func(t *testing.T) D, opts ...AdapterOption) Adapter {
+	a := Adapter{Name: name, setup: newSetup(start), setups: map[string]setup{}}
+	for _, opt := range opts {
+		opt(&a)
+	}
+	return a
+}
+
+// setupFor returns how a starts drivers for spec, and false when spec needs
+// a setup a doesn't have.
+func (a Adapter) setupFor(spec Specification) (setup, bool) {
+	if s, ok := a.setups[spec.Name]; ok {
+		return s, true
+	}
+	return a.setup, !spec.needsSetup
+}
+
+// Outcome is how checking one adapter against one specification went.
+type Outcome int
+
+const (
+	// NotRun means the check was filtered out, e.g. by go test -run.
+	NotRun Outcome = iota
+	Passed
+	Failed
+	Skipped
+	// NotApplicable means the adapter's driver doesn't implement what the
+	// specification needs, or the specification needs a setup the adapter
+	// doesn't have.
+	NotApplicable
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Passed:
+		return "PASS"
+	case Failed:
+		return "FAIL"
+	case Skipped:
+		return "SKIP"
+	case NotApplicable:
+		return "-"
+	default:
+		return "not run"
+	}
+}
+
+// Result is one cell of the matrix.
+type Result struct {
+	Specification string
+	// Description is the specification's.
+	Description string
+	Adapter     string
+	Outcome     Outcome
+	Duration    time.Duration
+	// Steps are how each step of the specification went, in the order they
+	// started.
+	Steps []Step
+	// test is the name of the subtest the cell ran as.
+	test string
+}
+
+// DefaultMatrix has every specification. The authentication and throttling
+// specifications need drivers set up for them, so they're only checked
+// against adapters registered with a setup for them.
+var DefaultMatrix = NewMatrix(
+	NewSpecification("Greet",
+		"Greets people by name, or greets the world when there's no name, tidying up whitespace and accents.",
+		GreetSpecification),
+	NewSpecification("Curse",
+		"Curses people by name, tidying up whitespace and accents.",
+		CurseSpecification),
+	NewSpecification("LocalisedGreet",
+		"Greets people in the language they prefer, falling back to English for languages it doesn't speak.",
+		LocalisedGreetSpecification),
+	NewSpecification("LocalisedCurse",
+		"Curses people in the language they prefer, falling back to English for languages it doesn't speak.",
+		LocalisedCurseSpecification),
+	NewSpecification("GreetNameValidation",
+		"Refuses to greet names that are too long or contain control characters.",
+		GreetNameValidationSpecification),
+	NewSpecification("CurseNameValidation",
+		"Refuses to curse names that are too long or contain control characters.",
+		CurseNameValidationSpecification),
+	NewSpecification("AwkwardName",
+		"Greets names with emoji, right-to-left scripts and characters that mean something to URLs or HTML exactly as they were given.",
+		AwkwardNameSpecification),
+	NewSpecification("Interaction",
+		"Offers every registered interaction in every supported language, and refuses interactions it doesn't know.",
+		InteractionSpecification),
+	NewSpecification("Moderation",
+		"Refuses offensive names, however they're disguised, without refusing innocent names that contain offensive words.",
+		ModerationSpecification),
+	NewSpecification("History",
+		"Remembers who was greeted and cursed, and lets you search what it remembers.",
+		HistorySpecification),
+	NewSpecification("GreetMany",
+		"Greets a batch of names in order, stopping at the first invalid one.",
+		GreetManySpecification),
+	NewSpecification("LocalisedGreetMany",
+		"Greets a batch of names in the language they prefer.",
+		LocalisedGreetManySpecification),
+	NewSpecification("Conversation",
+		"Greets names as they arrive over a stream, in order, stopping at the first invalid one.",
+		ConversationSpecification),
+	NewSpecification("GreetProperty",
+		"Greets any made-up name with the name, the same way every time.",
+		GreetPropertySpecification),
+	NewSpecification("CurseProperty",
+		"Curses any made-up name with the name, the same way every time.",
+		CursePropertySpecification),
+	NewSpecification("CurseThrottle",
+		"Refuses curses from callers who curse too often, saying when to try again, but still greets them.",
+		CurseThrottleSpecification, NeedsSetup()),
+	NewSpecification("CurseAuthentication",
+		"Greets anonymous callers, but refuses to curse them.",
+		CurseAuthenticationSpecification, NeedsSetup()),
+	NewSpecification("InvalidCredentials",
+		"Refuses callers whose credentials it doesn't accept, whatever they ask for.",
+		InvalidCredentialsSpecification, NeedsSetup()),
+)
+
+// Matrix checks every adapter registered with it against every
+// specification registered with it.
+type Matrix struct {
+	mu             sync.RWMutex
+	specifications []Specification
+	adapters       []Adapter
+}
+
+func NewMatrix(specifications ...Specification) *Matrix {
+	return &Matrix{specifications: specifications}
+}
+
+// RegisterSpecification adds specifications that every adapter is checked
+// against.
+func (m *Matrix) RegisterSpecification(specifications ...Specification) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.specifications = append(m.specifications, specifications...)
+}
+
+// RegisterAdapter adds adapters to check against every specification.
+func (m *Matrix) RegisterAdapter(adapters ...Adapter) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.adapters = append(m.adapters, adapters...)
+}
+
+// Run checks each adapter against each specification in parallel, as
+// subtests named specification/adapter, and logs a summary of the results,
+// which it returns in specification then adapter order.
+func (m *Matrix) Run(t *testing.T) []Result {
+	t.Helper()
+	m.mu.RLock()
+	specifications := append([]Specification(nil), m.specifications...)
+	adapters := append([]Adapter(nil), m.adapters...)
+	m.mu.RUnlock()
+
+	results := make([]Result, 0, len(specifications)*len(adapters))
+	for _, spec := range specifications {
+		for _, adapter := range adapters {
+			results = append(results, Result{Specification: spec.Name, Description: spec.Description, Adapter: adapter.Name})
+		}
+	}
+
+	prefix := t.Name() + "/matrix/"
+	steps.start(prefix)
+	defer steps.stop(prefix)
+
+	// Parallel subtests finish before the group they're in does.
+	t.Run("matrix", func(t *testing.T) {
+		for i, spec := range specifications {
+			t.Run(spec.Name, func(t *testing.T) {
+				t.Parallel()
+				for j, adapter := range adapters {
+					result := &results[i*len(adapters)+j]
+					t.Run(adapter.Name, func(t *testing.T) {
+						t.Parallel()
+						runCell(t, spec, adapter, result)
+					})
+				}
+			})
+		}
+	})
+
+	for i := range results {
+		if results[i].test != "" {
+			results[i].Steps = steps.under(results[i].test)
+		}
+	}
+
+	var summary strings.Builder
+	if err := WriteSummary(&summary, results); err != nil {
+		t.Error(err)
+	}
+	t.Log("\n" + summary.String())
+	if dir := os.Getenv(ReportDirEnv); dir != "" {
+		if err := WriteReports(dir, results); err != nil {
+			t.Error(err)
+		}
+	}
+	return results
+}
+
+// runCell checks adapter against spec, recording how it went in result.
+func runCell(t *testing.T, spec Specification, adapter Adapter, result *Result) {
+	start := time.Now()
+	result.test = t.Name()
+	t.Cleanup(func() {
+		result.Duration = time.Since(start)
+		if result.Outcome != NotApplicable || t.Failed() {
+			result.Outcome = outcomeOf(t)
+		}
+	})
+
+	setup, ok := adapter.setupFor(spec)
+	switch {
+	case !ok:
+		result.Outcome = NotApplicable
+		t.Skipf("%s has no setup for %s", adapter.Name, spec.Name)
+	case !spec.AppliesTo(adapter):
+		result.Outcome = NotApplicable
+		t.Skipf("%s's driver, %s, isn't a %s", adapter.Name, setup.driver, spec.needs)
+	}
+	spec.check(t, startAdapter(t, adapter.Name, setup))
+}
+
+func outcomeOf(t *testing.T) Outcome {
+	switch {
+	case t.Failed():
+		return Failed
+	case t.Skipped():
+		return Skipped
+	default:
+		return Passed
+	}
+}
+
+// startAdapter fails the cell, rather than crashing every other cell, if the
+// adapter panics while starting, e.g. because there's no browser or Docker.
+func startAdapter(t *testing.T, name string, setup setup) (driver any) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("starting %s: %v", name, r)
+		}
+	}()
+	return setup.start(t)
+}
+
+// WriteSummary writes results as a table of specifications by adapters.
+func WriteSummary(w io.Writer, results []Result) error {
+	var (
+		specifications []string
+		adapters       []string
+		outcomes       = map[[2]string]Outcome{}
+		counts         = map[Outcome]int{}
+	)
+	for _, result := range results {
+		specifications = appendNew(specifications, result.Specification)
+		adapters = appendNew(adapters, result.Adapter)
+		outcomes[[2]string{result.Specification, result.Adapter}] = result.Outcome
+		counts[result.Outcome]++
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(table, "specification")
+	for _, adapter := range adapters {
+		fmt.Fprintf(table, "\t%s", adapter)
+	}
+	fmt.Fprintln(table)
+	for _, spec := range specifications {
+		fmt.Fprint(table, spec)
+		for _, adapter := range adapters {
+			fmt.Fprintf(table, "\t%s", outcomes[[2]string{spec, adapter}])
+		}
+		fmt.Fprintln(table)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%d passed, %d failed, %d skipped, %d not applicable, %d not run\n",
+		counts[Passed], counts[Failed], counts[Skipped], counts[NotApplicable], counts[NotRun])
+	return err
+}
+
+func appendNew(names []string, name string) []string {
+	for _, n := range names {
+		if n == name {
+			return names
+		}
+	}
+	return append(names, name)
+}
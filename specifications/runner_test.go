@@ -0,0 +1,152 @@
+package specifications_test
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+// greetsMike is a specification for drivers that greet Mike with want.
+func greetsMike(name string, want string, opts ...specifications.SpecificationOption) specifications.Specification {
+	return specifications.NewSpecification(name, "Greets Mike.", func(t *testing.T, greeter specifications.Greeter) {
+		got, err := greeter.Greet(context.Background(), "Mike")
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}, opts...)
+}
+
+// greeter starts drivers that greet with greeting.
+func greeter(greeting string) func(t *testing.T) specifications.GreetAdapter {
+	return func(t *testing.T) specifications.GreetAdapter {
+		return func(name string) (string, error) {
+			return greeting + ", " + name, nil
+		}
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	matrix := specifications.NewMatrix(
+		greetsMike("Greet", "Hello, Mike"),
+		greetsMike("Whisper", "Psst, Mike", specifications.NeedsSetup()),
+		specifications.NewSpecification("GreetMany", "Greets batches.", func(t *testing.T, greeter specifications.BatchGreeter) {
+			t.Error("checked a driver that doesn't greet batches")
+		}),
+	)
+	matrix.RegisterAdapter(
+		specifications.NewAdapter("whisperer", greeter("Hello"),
+			specifications.WithSetup("Whisper", greeter("Psst")),
+		),
+		specifications.NewAdapter("shouter", greeter("Hello")),
+		specifications.NewAdapter("skipper", func(t *testing.T) specifications.GreetAdapter {
+			t.Skip("nothing to drive")
+			return nil
+		}),
+	)
+
+	outcomes := map[string]specifications.Outcome{}
+	for _, result := range matrix.Run(t) {
+		outcomes[result.Specification+"/"+result.Adapter] = result.Outcome
+	}
+
+	assert.Equal(t, map[string]specifications.Outcome{
+		"Greet/whisperer":     specifications.Passed,
+		"Greet/shouter":       specifications.Passed,
+		"Greet/skipper":       specifications.Skipped,
+		"Whisper/whisperer":   specifications.Passed,
+		"Whisper/shouter":     specifications.NotApplicable,
+		"Whisper/skipper":     specifications.NotApplicable,
+		"GreetMany/whisperer": specifications.NotApplicable,
+		"GreetMany/shouter":   specifications.NotApplicable,
+		"GreetMany/skipper":   specifications.NotApplicable,
+	}, outcomes)
+}
+
+// domainCurses is a matrix checking the domain against CurseSpecification.
+func domainCurses() *specifications.Matrix {
+	matrix := specifications.NewMatrix(specifications.NewSpecification("Curse", "Curses.", specifications.CurseSpecification))
+	matrix.RegisterAdapter(specifications.NewAdapter("domain", func(t *testing.T) specifications.CurseAdapter {
+		return interactions.Curse
+	}))
+	return matrix
+}
+
+func TestMatrixSteps(t *testing.T) {
+	results := domainCurses().Run(t)
+
+	var want []specifications.Step
+	for _, scenario := range specifications.CurseScenarios.All() {
+		want = append(want, specifications.Step{Name: scenario.Description, Outcome: specifications.Passed})
+	}
+	var got []specifications.Step
+	for _, step := range results[0].Steps {
+		got = append(got, specifications.Step{Name: step.Name, Outcome: step.Outcome})
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestMatrixStepNames(t *testing.T) {
+	out := runSubprocess(t, "TestMatrixWithUnderscoredStep")
+	assert.Contains(t, out, `step: "snake_case name"`)
+	assert.Contains(t, out, `step: "surrounding whitespace"`)
+}
+
+func TestMatrixWithUnderscoredStep(t *testing.T) {
+	skipUnlessSubprocess(t)
+	specifications.CurseScenarios.Register(specifications.Scenario{Description: "snake_case name", Name: "snake_case", Want: "Go to hell, snake_case!"})
+	for _, step := range domainCurses().Run(t)[0].Steps {
+		t.Logf("step: %q", step.Name)
+	}
+}
+
+func TestMatrixFailures(t *testing.T) {
+	out := runSubprocess(t, "TestMatrixWithFailingAdapters")
+
+	t.Run("fails cells whose specification fails", func(t *testing.T) {
+		assert.Contains(t, out, "--- FAIL: TestMatrixWithFailingAdapters/matrix/Greet/rude")
+		assert.Contains(t, out, "result: Greet/rude FAIL")
+	})
+
+	t.Run("fails cells whose adapter panics while starting, without crashing the others", func(t *testing.T) {
+		assert.Contains(t, out, "starting crashing: no browser")
+		assert.Contains(t, out, "result: Greet/crashing FAIL")
+		assert.Contains(t, out, "result: Greet/polite PASS")
+	})
+
+	t.Run("logs a summary", func(t *testing.T) {
+		assert.True(t, regexp.MustCompile(`Greet\s+PASS\s+FAIL\s+FAIL`).MatchString(out), out)
+		assert.Contains(t, out, "1 passed, 2 failed, 0 skipped, 0 not applicable, 0 not run")
+	})
+}
+
+func TestMatrixWithFailingAdapters(t *testing.T) {
+	skipUnlessSubprocess(t)
+	matrix := specifications.NewMatrix(greetsMike("Greet", "Hello, Mike"))
+	matrix.RegisterAdapter(
+		specifications.NewAdapter("polite", greeter("Hello")),
+		specifications.NewAdapter("rude", greeter("Go away")),
+		specifications.NewAdapter("crashing", func(t *testing.T) specifications.GreetAdapter {
+			panic("no browser")
+		}),
+	)
+	for _, result := range matrix.Run(t) {
+		t.Logf("result: %s/%s %s", result.Specification, result.Adapter, result.Outcome)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	var out strings.Builder
+	assert.NoError(t, specifications.WriteSummary(&out, results))
+
+	assert.Equal(t, strings.Join([]string{
+		"specification  domain  httpserver",
+		"Greet          PASS    FAIL",
+		"Conversation   -       not run",
+		"1 passed, 1 failed, 0 skipped, 1 not applicable, 1 not run",
+		"",
+	}, "\n"), out.String())
+}
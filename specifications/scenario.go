@@ -0,0 +1,91 @@
+package specifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// Scenario is a name given to an adapter and what it should reply with, or
+// the error it should fail with when WantErr is set.
+type Scenario struct {
+	Description string
+	Name        string
+	Want        string
+	WantErr     error
+}
+
+// Scenarios is a table of scenarios that projects can add their own to.
+type Scenarios struct {
+	mu        sync.RWMutex
+	scenarios []Scenario
+}
+
+func NewScenarios(scenarios ...Scenario) *Scenarios {
+	return &Scenarios{scenarios: scenarios}
+}
+
+// Register adds scenarios to the table, so every adapter checked against it
+// is held to them too. Register them before the specifications run, e.g.
+// from TestMain.
+func (s *Scenarios) Register(scenarios ...Scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scenarios = append(s.scenarios, scenarios...)
+}
+
+// All returns the scenarios in the order they were registered.
+func (s *Scenarios) All() []Scenario {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Scenario(nil), s.scenarios...)
+}
+
+// GreetScenarios are what GreetSpecification checks.
+var GreetScenarios = NewScenarios(
+	Scenario{Description: "name", Name: "Mike", Want: "Hello, Mike"},
+	Scenario{Description: "empty name", Name: "", Want: "Hello, World"},
+	Scenario{Description: "only whitespace", Name: "   ", Want: "Hello, World"},
+	Scenario{Description: "surrounding whitespace", Name: "  Mike ", Want: "Hello, Mike"},
+	Scenario{Description: "accented name", Name: "Zoë", Want: "Hello, Zoë"},
+	Scenario{Description: "decomposed accents", Name: "Zoe\u0308", Want: "Hello, Zoë"},
+	Scenario{Description: "name in another alphabet", Name: "Μιχάλης", Want: "Hello, Μιχάλης"},
+	Scenario{Description: "control characters", Name: "Mi\u0085ke", WantErr: interactions.ErrNameHasControlCharacters},
+)
+
+// CurseScenarios are what CurseSpecification checks.
+var CurseScenarios = NewScenarios(
+	Scenario{Description: "name", Name: "Chris", Want: "Go to hell, Chris!"},
+	Scenario{Description: "surrounding whitespace", Name: " Chris  ", Want: "Go to hell, Chris!"},
+	Scenario{Description: "accented name", Name: "Chloé", Want: "Go to hell, Chloé!"},
+	Scenario{Description: "control characters", Name: "Ch\u0007ris", WantErr: interactions.ErrNameHasControlCharacters},
+)
+
+// runScenarios runs each scenario as a subtest, calling interact with its
+// name. Failures name the adapter, what was called and with what.
+func runScenarios(t *testing.T, adapter any, method string, scenarios *Scenarios, interact func(ctx context.Context, name string) (string, error)) {
+	t.Helper()
+	name := adapterName(adapter)
+	for _, scenario := range scenarios.All() {
+		step(t, scenario.Description, func(t *testing.T) {
+			call := fmt.Sprintf("%s.%s(%q)", name, method, scenario.Name)
+			got, err := interact(contextFor(t), scenario.Name)
+			if scenario.WantErr != nil {
+				assert.IsError(t, err, scenario.WantErr, "%s", call)
+				return
+			}
+			assert.NoError(t, err, "%s", call)
+			assert.Equal(t, scenario.Want, got, "%s", call)
+		})
+	}
+}
+
+// adapterName is the driver's type, e.g. "httpserver.Driver".
+func adapterName(adapter any) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", adapter), "*")
+}
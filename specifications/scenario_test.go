@@ -0,0 +1,61 @@
+package specifications_test
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+	"github.com/quii/go-specs-greet/specifications"
+)
+
+// subprocessEnv is set when the test binary runs one of its own tests whose
+// failures are what's being tested.
+const subprocessEnv = "SPECIFICATIONS_TEST_SUBPROCESS"
+
+// runSubprocess runs test in a fresh copy of the test binary and returns its
+// verbose output, so test can fail without failing the test checking how.
+func runSubprocess(t *testing.T, test string) string {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^"+test+"$", "-test.v", "-test.count=1")
+	cmd.Env = append(os.Environ(), subprocessEnv+"=1")
+	out, err := cmd.CombinedOutput()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func skipUnlessSubprocess(t *testing.T) {
+	if os.Getenv(subprocessEnv) == "" {
+		t.Skip("only runs as a subprocess of another test")
+	}
+}
+
+func TestScenariosRegister(t *testing.T) {
+	out := runSubprocess(t, "TestGreetWithRegisteredScenarios")
+
+	t.Run("runs registered scenarios as named subtests", func(t *testing.T) {
+		assert.Contains(t, out, "--- PASS: TestGreetWithRegisteredScenarios/nickname")
+	})
+
+	t.Run("reports failing scenarios with the adapter and the call", func(t *testing.T) {
+		assert.Contains(t, out, "--- FAIL: TestGreetWithRegisteredScenarios/Texan_greeting")
+		assert.Contains(t, out, `specifications.GreetAdapter.Greet("Pepper")`)
+		assert.Contains(t, out, "Howdy, Pepper")
+	})
+
+	t.Run("still runs the built-in scenarios", func(t *testing.T) {
+		assert.Contains(t, out, "--- PASS: TestGreetWithRegisteredScenarios/name")
+	})
+}
+
+func TestGreetWithRegisteredScenarios(t *testing.T) {
+	skipUnlessSubprocess(t)
+	specifications.GreetScenarios.Register(
+		specifications.Scenario{Description: "nickname", Name: "Pep", Want: "Hello, Pep"},
+		specifications.Scenario{Description: "Texan greeting", Name: "Pepper", Want: "Howdy, Pepper"},
+	)
+	specifications.GreetSpecification(t, specifications.GreetAdapter(interactions.Greet))
+}
@@ -0,0 +1,154 @@
+package specifications
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Step is how one step of a specification, e.g. one scenario, went.
+type Step struct {
+	// Name is the step's subtest name, relative to the specification's, as
+	// the specification wrote it rather than as go test rewrote it.
+	Name     string
+	Outcome  Outcome
+	Duration time.Duration
+}
+
+// steps records every step of a Matrix.Run that asks for a context, as it
+// finishes.
+var steps = &stepLog{runs: map[string]bool{}, recorded: map[string]bool{}, finished: map[string]Step{}, written: map[string]writtenName{}}
+
+type stepLog struct {
+	mu sync.Mutex
+	// runs are the name prefixes of the subtests of matrix runs in progress.
+	runs     map[string]bool
+	recorded map[string]bool
+	// tests are the full names of the steps' subtests, in the order they
+	// started, and finished their outcomes.
+	tests    []string
+	finished map[string]Step
+	// written are the names subtests were given by step, by their full
+	// names.
+	written map[string]writtenName
+}
+
+// writtenName is what step named a subtest of parent.
+type writtenName struct {
+	parent string
+	name   string
+}
+
+// step runs f as a subtest named name, like t.Run, remembering name as it
+// was written for reports, because go test rewrites spaces and other
+// characters in subtests' names.
+func step(t *testing.T, name string, f func(t *testing.T)) bool {
+	t.Helper()
+	parent := t.Name()
+	return t.Run(name, func(t *testing.T) {
+		steps.name(t, parent, name)
+		f(t)
+	})
+}
+
+// start records the steps of subtests whose names start with prefix, until
+// stop is called with it.
+func (l *stepLog) start(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.runs[prefix] = true
+}
+
+// stop stops recording the steps of subtests whose names start with prefix,
+// and forgets those it recorded.
+func (l *stepLog) stop(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.runs, prefix)
+	tests := l.tests[:0]
+	for _, name := range l.tests {
+		if !strings.HasPrefix(name, prefix) {
+			tests = append(tests, name)
+			continue
+		}
+		delete(l.recorded, name)
+		delete(l.finished, name)
+	}
+	l.tests = tests
+	for name := range l.written {
+		if strings.HasPrefix(name, prefix) {
+			delete(l.written, name)
+		}
+	}
+}
+
+// name remembers that t is the subtest of parent that step named name.
+func (l *stepLog) name(t *testing.T, parent string, name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.running(t.Name()) {
+		l.written[t.Name()] = writtenName{parent: parent, name: name}
+	}
+}
+
+// record notes the step t runs, once however many contexts it asks for, and
+// records its outcome when it finishes. It ignores steps that aren't part of
+// a matrix run.
+func (l *stepLog) record(t *testing.T) {
+	name := t.Name()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.recorded[name] || !l.running(name) {
+		return
+	}
+	l.recorded[name] = true
+	l.tests = append(l.tests, name)
+
+	start := time.Now()
+	t.Cleanup(func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.finished[name] = Step{Outcome: outcomeOf(t), Duration: time.Since(start)}
+	})
+}
+
+func (l *stepLog) running(name string) bool {
+	for prefix := range l.runs {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// under returns the finished steps run by test's subtests, named relative to
+// it.
+func (l *stepLog) under(test string) []Step {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var found []Step
+	for _, name := range l.tests {
+		step, finished := l.finished[name]
+		if !strings.HasPrefix(name, test+"/") || !finished {
+			continue
+		}
+		step.Name = l.writtenUnder(test, name)
+		found = append(found, step)
+	}
+	return found
+}
+
+// writtenUnder is the subtest named name, relative to test, as step named
+// the subtests it's made of, or as go test did where step didn't.
+func (l *stepLog) writtenUnder(test string, name string) string {
+	written, ok := l.written[name]
+	switch {
+	case ok && written.parent == test:
+		return written.name
+	case ok && strings.HasPrefix(written.parent, test+"/"):
+		return l.writtenUnder(test, written.parent) + "/" + written.name
+	default:
+		return strings.TrimPrefix(name, test+"/")
+	}
+}
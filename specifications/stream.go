@@ -0,0 +1,78 @@
+package specifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+type BatchGreeter interface {
+	GreetMany(ctx context.Context, names []string) ([]string, error)
+}
+
+type LocalisedBatchGreeter interface {
+	GreetManyIn(ctx context.Context, locale string, names []string) ([]string, error)
+}
+
+type Conversationalist interface {
+	Converse(ctx context.Context, names []string) ([]string, error)
+}
+
+func GreetManySpecification(t *testing.T, greeter BatchGreeter) {
+	greetManySpecification(t, greeter.GreetMany)
+}
+
+func LocalisedGreetManySpecification(t *testing.T, greeter LocalisedBatchGreeter) {
+	for locale, want := range map[string][]string{
+		"fr":        {"Bonjour, Mike", "Bonjour, tout le monde"},
+		"de-AT, en": {"Hallo, Mike", "Hallo, Welt"},
+		"tlh":       {"Hello, Mike", "Hello, World"},
+	} {
+		step(t, locale, func(t *testing.T) {
+			got, err := greeter.GreetManyIn(contextFor(t), locale, []string{"Mike", ""})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func ConversationSpecification(t *testing.T, conversationalist Conversationalist) {
+	greetManySpecification(t, conversationalist.Converse)
+}
+
+func greetManySpecification(t *testing.T, greetMany func(ctx context.Context, names []string) ([]string, error)) {
+	step(t, "greets each name in order", func(t *testing.T) {
+		got, err := greetMany(contextFor(t), []string{"Mike", "Chris", ""})
+		assert.NoError(t, err)
+		assert.Equal(t, got, []string{"Hello, Mike", "Hello, Chris", "Hello, World"})
+	})
+
+	step(t, "greets large batches", func(t *testing.T) {
+		var names, want []string
+		for i := range 1000 {
+			name := fmt.Sprintf("Guest %d", i)
+			names = append(names, name)
+			want = append(want, "Hello, "+name)
+		}
+
+		got, err := greetMany(contextFor(t), names)
+		assert.NoError(t, err)
+		assert.Equal(t, got, want)
+	})
+
+	step(t, "greets nobody when given no names", func(t *testing.T) {
+		got, err := greetMany(contextFor(t), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, len(got), 0)
+	})
+
+	step(t, "stops at the first invalid name", func(t *testing.T) {
+		got, err := greetMany(contextFor(t), []string{"Mike", strings.Repeat("a", interactions.MaxNameLength+1), "Chris"})
+		assert.IsError(t, err, interactions.ErrNameTooLong)
+		assert.Equal(t, got, []string{"Hello, Mike"})
+	})
+}
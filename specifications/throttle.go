@@ -0,0 +1,40 @@
+package specifications
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+// maxCursesBeforeThrottling is more curses than any sensible limit allows.
+const maxCursesBeforeThrottling = 1000
+
+// CurseThrottleSpecification curses until the adapter refuses, so greeter
+// should be throttled after a few curses, and not shared with other
+// specifications.
+func CurseThrottleSpecification(t *testing.T, greeter FullGreeter) {
+	ctx := contextFor(t)
+
+	var err error
+	for i := 0; i < maxCursesBeforeThrottling && err == nil; i++ {
+		_, err = greeter.Curse(ctx, "Chris")
+	}
+
+	step(t, "refuses curses past the limit", func(t *testing.T) {
+		assert.IsError(t, err, interactions.ErrTooManyRequests)
+	})
+
+	step(t, "says when to try again", func(t *testing.T) {
+		var throttled *interactions.ThrottledError
+		assert.True(t, errors.As(err, &throttled), "%v doesn't say when to retry", err)
+		assert.True(t, throttled.RetryAfter > 0, "retry after %s", throttled.RetryAfter)
+	})
+
+	step(t, "still greets", func(t *testing.T) {
+		got, err := greeter.Greet(contextFor(t), "Mike")
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, Mike", got)
+	})
+}
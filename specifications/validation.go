@@ -0,0 +1,35 @@
+package specifications
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+	"github.com/quii/go-specs-greet/domain/interactions"
+)
+
+var invalidNames = map[string]struct {
+	name string
+	want error
+}{
+	"too long":           {name: strings.Repeat("a", interactions.MaxNameLength+1), want: interactions.ErrNameTooLong},
+	"control characters": {name: "Mi\u0085ke", want: interactions.ErrNameHasControlCharacters},
+}
+
+func GreetNameValidationSpecification(t *testing.T, greeter Greeter) {
+	for description, tc := range invalidNames {
+		step(t, description, func(t *testing.T) {
+			_, err := greeter.Greet(contextFor(t), tc.name)
+			assert.IsError(t, err, tc.want)
+		})
+	}
+}
+
+func CurseNameValidationSpecification(t *testing.T, meany MeanGreeter) {
+	for description, tc := range invalidNames {
+		step(t, description, func(t *testing.T) {
+			_, err := meany.Curse(contextFor(t), tc.name)
+			assert.IsError(t, err, tc.want)
+		})
+	}
+}